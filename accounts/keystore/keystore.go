@@ -279,7 +279,10 @@ func (ks *KeyStore) SignTx(a accounts.Account, tx *types.Transaction, chainID *b
 		return nil, ErrLocked
 	}
 	// Depending on the presence of the chain ID, sign with 2718 or homestead
-	signer := types.LatestSignerForChainID(chainID)
+	signer, err := types.LatestSignerForChainIDSafe(chainID)
+	if err != nil {
+		return nil, err
+	}
 	return types.SignTx(tx, signer, unlockedKey.PrivateKey)
 }
 
@@ -304,7 +307,10 @@ func (ks *KeyStore) SignTxWithPassphrase(a accounts.Account, passphrase string,
 	}
 	defer zeroKey(key.PrivateKey)
 	// Depending on the presence of the chain ID, sign with or without replay protection.
-	signer := types.LatestSignerForChainID(chainID)
+	signer, err := types.LatestSignerForChainIDSafe(chainID)
+	if err != nil {
+		return nil, err
+	}
 	return types.SignTx(tx, signer, key.PrivateKey)
 }
 
@@ -397,7 +397,10 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction
 	if chainID == nil {
 		signer = new(types.HomesteadSigner)
 	} else {
-		signer = types.LatestSignerForChainID(chainID)
+		var err error
+		if signer, err = types.LatestSignerForChainIDSafe(chainID); err != nil {
+			return common.Address{}, nil, err
+		}
 		// For non-legacy transactions, V is 0 or 1, no need to subtract here.
 		if tx.Type() == types.LegacyTxType {
 			signature[64] -= byte(chainID.Uint64()*2 + 35)
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"crypto/sha256"
-	"errors"
 	"flag"
 	"fmt"
 	"math/big"
@@ -16,12 +14,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/testutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/holiman/uint256"
 )
 
 func main() {
@@ -43,17 +39,7 @@ func main() {
 
 	// Configure a fork-rule set where Cancun is active and Osaka is not, so we
 	// exercise legacy blob-proof verification (VerifyBlobProof).
-	chainConfig := *params.TestChainConfig
-	chainConfig.CancunTime = u64(0)
-	chainConfig.PragueTime = nil
-	chainConfig.OsakaTime = nil
-	chainConfig.BlobScheduleConfig = &params.BlobScheduleConfig{
-		Cancun: &params.BlobConfig{
-			Target:         3,
-			Max:            6,
-			UpdateFraction: params.DefaultCancunBlobConfig.UpdateFraction,
-		},
-	}
+	chainConfig := *params.CancunTestChainConfig
 	head := &types.Header{
 		Number:     big.NewInt(1),
 		Time:       1,
@@ -62,19 +48,23 @@ func main() {
 		GasLimit:   30_000_000,
 	}
 
-	makeTx, err := newInvalidBlobTxMaker(&chainConfig)
+	makeTx, err := testutil.NewInvalidTxMaker(types.BlobTxType, &chainConfig)
 	if err != nil {
 		fatalf("failed to initialize invalid blob tx maker: %v", err)
 	}
 
 	opts := &txpool.ValidationOptions{
-		Config:       &chainConfig,
-		Accept:       1 << types.BlobTxType,
-		MaxSize:      1024 * 1024,
-		MaxBlobCount: 1,
-		MinTip:       big.NewInt(0),
+		Config:         &chainConfig,
+		Accept:         1 << types.BlobTxType,
+		MaxSize:        1024 * 1024,
+		MaxBlobCount:   1,
+		MinTip:         big.NewInt(0),
+		GasCapMultiple: 1,
+	}
+	signer, err := types.NewCancunSignerSafe(chainConfig.ChainID)
+	if err != nil {
+		fatalf("failed to create signer: %v", err)
 	}
-	signer := types.NewCancunSigner(chainConfig.ChainID)
 
 	// Build a TxFetcher whose addTxs callback runs stateless validation (incl. KZG),
 	// and whose dropPeer callback records whether it ever gets invoked.
@@ -138,14 +128,12 @@ func main() {
 	// Pre-generate a corpus of distinct txs (nonce-differentiated) so we don't
 	// spend significant time signing inside the hot loop. KZG verification is
 	// the intended hot spot.
-	corpusSize := max(1024, (*peers)*(*txsPerSend)*2)
-	corpus := make([]*types.Transaction, 0, corpusSize)
-	for i := 0; i < corpusSize; i++ {
-		tx, err := makeTx(uint64(i))
-		if err != nil {
-			fatalf("failed to create tx corpus item: %v", err)
-		}
-		corpus = append(corpus, tx)
+	corpus, err := buildCorpus(makeTx, max(1024, (*peers)*(*txsPerSend)*2))
+	if err != nil {
+		fatalf("failed to create tx corpus: %v", err)
+	}
+	if err := checkCorpus(corpus); err != nil {
+		fatalf("invalid tx corpus: %v", err)
 	}
 
 	var wg sync.WaitGroup
@@ -173,6 +161,7 @@ func main() {
 		}(peerID, uint64(peerIndex)<<32)
 	}
 	wg.Wait()
+	<-f.IdleNotify() // wait for any validations still in flight
 
 	v := validations.Load()
 	fa := failures.Load()
@@ -188,67 +177,35 @@ func main() {
 	}
 }
 
-func newInvalidBlobTxMaker(chainConfig *params.ChainConfig) (func(nonce uint64) (*types.Transaction, error), error) {
-	key, err := crypto.GenerateKey()
-	if err != nil {
-		return nil, err
-	}
-	chainID := uint256.MustFromBig(chainConfig.ChainID)
-
-	// Start from an all-zero blob (canonical), compute commitment+proof for it,
-	// then mutate the blob so the proof becomes invalid while remaining well-formed.
-	var blob kzg4844.Blob
-	commitment, err := kzg4844.BlobToCommitment(&blob)
-	if err != nil {
-		return nil, err
-	}
-	proof, err := kzg4844.ComputeBlobProof(&blob, commitment)
-	if err != nil {
-		return nil, err
-	}
-	mutated := blob
-	mutated[31] = 1 // keeps the first field element canonical, but changes the blob
-
-	vhash := kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
-	sidecar := types.NewBlobTxSidecar(
-		types.BlobSidecarVersion0,
-		[]kzg4844.Blob{mutated},
-		[]kzg4844.Commitment{commitment},
-		[]kzg4844.Proof{proof},
-	)
-	if err := sidecar.ValidateBlobCommitmentHashes([]common.Hash{vhash}); err != nil {
-		return nil, fmt.Errorf("unexpected commitment-hash validation failure: %w", err)
-	}
-
-	// Sanity: the proof must fail (otherwise we didn’t construct the intended case).
-	if err := kzg4844.VerifyBlobProof(&sidecar.Blobs[0], sidecar.Commitments[0], sidecar.Proofs[0]); err == nil {
-		return nil, errors.New("constructed blob proof unexpectedly verifies")
+// buildCorpus creates size transactions, differentiated by their nonce. The
+// nonce is taken modulo the corpus size, so every slot maps to a unique nonce
+// regardless of how the cursors later wrap around the corpus.
+func buildCorpus(makeTx func(nonce uint64) (*types.Transaction, error), size int) ([]*types.Transaction, error) {
+	corpus := make([]*types.Transaction, 0, size)
+	for i := 0; i < size; i++ {
+		tx, err := makeTx(uint64(i) % uint64(size))
+		if err != nil {
+			return nil, fmt.Errorf("corpus item %d: %w", i, err)
+		}
+		corpus = append(corpus, tx)
 	}
+	return corpus, nil
+}
 
-	signer := types.NewCancunSigner(chainID.ToBig())
-	minBlobFeeCap := new(big.Int).Add(big.NewInt(params.BlobTxMinBlobGasprice), big.NewInt(1))
-
-	return func(nonce uint64) (*types.Transaction, error) {
-		txData := &types.BlobTx{
-			ChainID:    chainID,
-			Nonce:      nonce,
-			GasTipCap:  uint256.NewInt(1),
-			GasFeeCap:  uint256.NewInt(2),
-			Gas:        21_000,
-			To:         common.Address{},
-			Value:      uint256.NewInt(0),
-			Data:       nil,
-			AccessList: nil,
-			BlobFeeCap: uint256.MustFromBig(minBlobFeeCap),
-			BlobHashes: []common.Hash{vhash},
-			Sidecar:    sidecar,
+// checkCorpus ensures no two corpus entries share a nonce. Since all entries are
+// signed by the same key, a collision would produce identical transactions and
+// the fetcher would skip the duplicates instead of validating them.
+func checkCorpus(corpus []*types.Transaction) error {
+	seen := make(map[uint64]int, len(corpus))
+	for i, tx := range corpus {
+		if j, ok := seen[tx.Nonce()]; ok {
+			return fmt.Errorf("nonce collision: entries %d and %d share nonce %d", j, i, tx.Nonce())
 		}
-		return types.SignNewTx(key, signer, txData)
-	}, nil
+		seen[tx.Nonce()] = i
+	}
+	return nil
 }
 
-func u64(v uint64) *uint64 { return &v }
-
 func fatalf(format string, args ...any) {
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
 	os.Exit(2)
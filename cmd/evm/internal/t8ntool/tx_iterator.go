@@ -138,7 +138,10 @@ func loadTransactions(txStr string, inputData *input, chainConfig *params.ChainC
 		txsWithKeys = inputData.Txs
 	}
 	// We may have to sign the transactions.
-	signer := types.LatestSignerForChainID(chainConfig.ChainID)
+	signer, err := types.LatestSignerForChainIDSafe(chainConfig.ChainID)
+	if err != nil {
+		return nil, NewError(ErrorConfig, fmt.Errorf("invalid chain ID: %v", err))
+	}
 	txs, err := signUnsignedTransactions(txsWithKeys, signer)
 	return newSliceTxIterator(txs), err
 }
@@ -0,0 +1,199 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// kzgbench measures the raw KZG throughput of the local machine, without any
+// networking or transaction pool overhead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	blobsFlag     = flag.Int("blobs", 1000, "number of blobs to process")
+	parallelFlag  = flag.Int("parallel", runtime.NumCPU(), "number of goroutines processing blobs")
+	operationFlag = flag.String("operation", "verify", "operation to benchmark (commitment, proof, verify)")
+	ckzgFlag      = flag.Bool("ckzg", false, "use the C KZG library instead of the Go one")
+)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-blobs <n>] [-parallel <n>] [-operation <op>] [-ckzg]")
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, `
+Measures the throughput of a single KZG operation over a set of random blobs.
+The inputs of the measured operation (commitments, proofs) are computed up
+front and are not included in the results.`)
+	}
+}
+
+// blobInput is a blob along with the inputs of the operations run on it.
+type blobInput struct {
+	blob       kzg4844.Blob
+	commitment kzg4844.Commitment
+	proof      kzg4844.Proof
+}
+
+// operations maps the benchmarkable operations to their implementation, along
+// with the inputs they need precomputed.
+var operations = map[string]struct {
+	needCommitment bool
+	needProof      bool
+	run            func(in *blobInput) error
+}{
+	"commitment": {
+		run: func(in *blobInput) error {
+			_, err := kzg4844.BlobToCommitment(&in.blob)
+			return err
+		},
+	},
+	"proof": {
+		needCommitment: true,
+		run: func(in *blobInput) error {
+			_, err := kzg4844.ComputeBlobProof(&in.blob, in.commitment)
+			return err
+		},
+	},
+	"verify": {
+		needCommitment: true,
+		needProof:      true,
+		run: func(in *blobInput) error {
+			return kzg4844.VerifyBlobProof(&in.blob, in.commitment, in.proof)
+		},
+	},
+}
+
+// process runs fn on every input on the given number of goroutines, returning
+// the first error encountered.
+func process(inputs []*blobInput, parallel int, fn func(in *blobInput) error) error {
+	var (
+		next atomic.Int64
+		fail atomic.Pointer[error]
+		wg   sync.WaitGroup
+	)
+	for range parallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := next.Add(1) - 1
+				if i >= int64(len(inputs)) || fail.Load() != nil {
+					return
+				}
+				if err := fn(inputs[i]); err != nil {
+					fail.CompareAndSwap(nil, &err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := fail.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func main() {
+	flag.Parse()
+
+	op, ok := operations[*operationFlag]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "Error: unknown operation", *operationFlag)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *blobsFlag <= 0 || *parallelFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: blob count and parallelism must be positive")
+		flag.Usage()
+		os.Exit(2)
+	}
+	// Initialize the KZG library up front, as loading the trusted setup would
+	// otherwise be accounted to the first measured operation
+	if err := kzg4844.UseCKZG(*ckzgFlag); err != nil {
+		die(err)
+	}
+	// Generate the blobs and the inputs of the measured operation
+	fmt.Printf("Preparing %d blobs for %s benchmark...\n", *blobsFlag, *operationFlag)
+
+	inputs := make([]*blobInput, *blobsFlag)
+	for i := range inputs {
+		blob, err := kzg4844.NewRandomBlob()
+		if err != nil {
+			die(err)
+		}
+		inputs[i] = &blobInput{blob: blob}
+	}
+	err := process(inputs, *parallelFlag, func(in *blobInput) (err error) {
+		if op.needCommitment {
+			if in.commitment, err = kzg4844.BlobToCommitment(&in.blob); err != nil {
+				return err
+			}
+		}
+		if op.needProof {
+			if in.proof, err = kzg4844.ComputeBlobProof(&in.blob, in.commitment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		die(err)
+	}
+	// Run the actual benchmark, tracking the CPU time burnt
+	var (
+		before, after metrics.CPUStats
+		busy          atomic.Int64 // Total time spent in the operation across goroutines
+	)
+	metrics.ReadCPUStats(&before)
+	start := time.Now()
+
+	err = process(inputs, *parallelFlag, func(in *blobInput) error {
+		defer func(start time.Time) { busy.Add(int64(time.Since(start))) }(time.Now())
+		return op.run(in)
+	})
+	if err != nil {
+		die(err)
+	}
+	elapsed := time.Since(start)
+	metrics.ReadCPUStats(&after)
+
+	var (
+		cpu  = after.LocalTime - before.LocalTime
+		util = 100 * cpu / (elapsed.Seconds() * float64(runtime.NumCPU()))
+	)
+	fmt.Printf("Operation:       %s\n", *operationFlag)
+	fmt.Printf("Blobs:           %d\n", len(inputs))
+	fmt.Printf("Goroutines:      %d\n", *parallelFlag)
+	fmt.Printf("Elapsed:         %v\n", elapsed)
+	fmt.Printf("Throughput:      %.2f blobs/sec\n", float64(len(inputs))/elapsed.Seconds())
+	fmt.Printf("Latency:         %.3f ms/blob\n", float64(busy.Load())/float64(time.Millisecond)/float64(len(inputs)))
+	fmt.Printf("CPU utilization: %.1f%% of %d cores (%.2fs CPU time)\n", util, runtime.NumCPU(), cpu)
+}
+
+func die(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// txreplay replays a transaction fetcher event log against a fresh fetcher.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+)
+
+var speed = flag.Float64("speed", 0, "replay speed relative to the recording (0 = as fast as possible)")
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-speed <factor>] <eventlog>")
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, `
+Replays the Enqueue calls recorded in a transaction fetcher event log against a
+fresh fetcher, reporting the resulting peer drops and penalties.
+
+The event log only contains transaction hashes, so every recorded transaction
+is substituted by a unique placeholder, which the pool stub accepts or rejects
+with the recorded validation error.`)
+	}
+}
+
+// replayErrors are the pool errors the fetcher reacts to specifically, which are
+// restored from the recorded messages so the replay behaves like the original.
+var replayErrors = []error{
+	txpool.ErrAlreadyKnown,
+	txpool.ErrReplaceUnderpriced,
+	txpool.ErrUnderpriced,
+	txpool.ErrTxGasPriceTooLow,
+}
+
+// replayer feeds recorded events into a transaction fetcher, tracking how it
+// reacted to them.
+type replayer struct {
+	fetcher *fetcher.TxFetcher
+	nonce   uint64 // Nonce of the next placeholder transaction
+
+	lock      sync.Mutex
+	results   map[common.Hash]error // Recorded validation results of the placeholders
+	rejects   map[string]int        // Number of rejections per error message
+	drops     map[string]int        // Number of drops requested per peer
+	penalties map[string]int        // Accumulated penalty score per peer
+}
+
+func newReplayer() *replayer {
+	r := &replayer{
+		results:   make(map[common.Hash]error),
+		rejects:   make(map[string]int),
+		drops:     make(map[string]int),
+		penalties: make(map[string]int),
+	}
+	config := fetcher.DefaultTxFetcherConfig
+	config.PeerPenaltyFn = r.penalize
+
+	r.fetcher = fetcher.NewTxFetcherWithConfig(config,
+		func(common.Hash, byte) error { return nil },
+		r.addTxs,
+		func(string, []common.Hash) error { return nil },
+		r.dropPeer,
+	)
+	return r
+}
+
+// addTxs is the pool stub returning the recorded validation results.
+func (r *replayer) addTxs(txs []*types.Transaction) []error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		errs[i] = r.results[tx.Hash()]
+		delete(r.results, tx.Hash())
+	}
+	return errs
+}
+
+func (r *replayer) dropPeer(peer string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.drops[peer]++
+}
+
+func (r *replayer) penalize(peer string, score int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.penalties[peer] += score
+}
+
+// replay substitutes the transactions of a recorded event with placeholders and
+// enqueues them into the fetcher.
+func (r *replayer) replay(event *fetcher.TxEvent) error {
+	if len(event.Errors) != len(event.Hashes) {
+		return fmt.Errorf("event from %s at %v: %d results for %d hashes", event.Peer, event.Time, len(event.Errors), len(event.Hashes))
+	}
+	txs := make([]*types.Transaction, len(event.Hashes))
+
+	r.lock.Lock()
+	for i := range event.Hashes {
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: r.nonce})
+		r.nonce++
+
+		if err := restoreError(event.Errors[i]); err != nil {
+			r.results[txs[i].Hash()] = err
+			r.rejects[event.Errors[i]]++
+		}
+	}
+	r.lock.Unlock()
+
+	return r.fetcher.Enqueue(event.Peer, txs, event.Direct)
+}
+
+// restoreError converts a recorded validation result back into an error, wrapping
+// the sentinel the message starts with, if any. Empty and spilled results are
+// considered accepted.
+func restoreError(msg string) error {
+	if msg == "" || msg == "spilled" {
+		return nil
+	}
+	for _, sentinel := range replayErrors {
+		if rest, ok := strings.CutPrefix(msg, sentinel.Error()); ok {
+			return fmt.Errorf("%w%s", sentinel, rest)
+		}
+	}
+	return errors.New(msg)
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		die(err)
+	}
+	defer file.Close()
+
+	r := newReplayer()
+	r.fetcher.Start()
+	defer r.fetcher.Stop()
+
+	var (
+		start  = time.Now()
+		events int
+		txs    int
+		last   time.Time
+	)
+	err = fetcher.ReadTxEventLog(file, func(event *fetcher.TxEvent) error {
+		if *speed > 0 && !last.IsZero() {
+			if gap := event.Time.Sub(last); gap > 0 {
+				time.Sleep(time.Duration(float64(gap) / *speed))
+			}
+		}
+		last = event.Time
+		events++
+		txs += len(event.Hashes)
+		return r.replay(event)
+	})
+	if err != nil {
+		die(err)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	fmt.Printf("Replayed %d events with %d transactions in %v\n", events, txs, time.Since(start))
+	report("Rejections", r.rejects)
+	report("Peer drops", r.drops)
+	report("Peer penalties", r.penalties)
+}
+
+// report prints a titled, sorted breakdown of a counter set.
+func report(title string, counts map[string]int) {
+	fmt.Printf("\n%s:\n", title)
+	if len(counts) == 0 {
+		fmt.Println("  none")
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		fmt.Printf("  %-60s %d\n", key, counts[key])
+	}
+}
+
+func die(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/syncer"
@@ -235,6 +236,12 @@ var (
 		Usage:    "Comma separated block number-to-hash mappings to require for peering (<number>=<hash>)",
 		Category: flags.EthCategory,
 	}
+	EthMaxTxFetchResponseRateFlag = &cli.IntFlag{
+		Name:     "eth.maxtxfetchresponserate",
+		Usage:    "Maximum number of pooled transaction requests served per peer per second, any more get an empty reply (0 = unlimited)",
+		Value:    ethconfig.Defaults.MaxTxFetchResponseRate,
+		Category: flags.EthCategory,
+	}
 	BloomFilterSizeFlag = &cli.Uint64Flag{
 		Name:     "bloomfilter.size",
 		Usage:    "Megabytes of memory allocated to bloom-filter for pruning",
@@ -446,6 +453,12 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolBlobValidationWorkersFlag = &cli.IntFlag{
+		Name:     "txpool.blobvalidationworkers",
+		Usage:    "Number of blob transaction batches to validate (KZG verify) concurrently; higher values improve throughput at the cost of latency spikes",
+		Value:    fetcher.DefaultTxFetcherConfig.BlobQueueWorkers,
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -465,6 +478,12 @@ var (
 		Value:    ethconfig.Defaults.BlobPool.PriceBump,
 		Category: flags.BlobPoolCategory,
 	}
+	BlobPoolProofCacheFlag = &cli.IntFlag{
+		Name:     "blobpool.proofcache",
+		Usage:    "Number of verified blob proofs to cache to avoid re-verification (0 = disabled)",
+		Value:    ethconfig.Defaults.BlobPool.ProofCacheSize,
+		Category: flags.BlobPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -1564,6 +1583,9 @@ func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
 	if ctx.IsSet(BlobPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(BlobPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(BlobPoolProofCacheFlag.Name) {
+		cfg.ProofCacheSize = ctx.Int(BlobPoolProofCacheFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -1630,6 +1652,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
 	setBlobPool(ctx, &cfg.BlobPool)
+	if ctx.IsSet(TxPoolBlobValidationWorkersFlag.Name) {
+		cfg.BlobValidationWorkers = ctx.Int(TxPoolBlobValidationWorkersFlag.Name)
+	}
+	if ctx.IsSet(EthMaxTxFetchResponseRateFlag.Name) {
+		cfg.MaxTxFetchResponseRate = ctx.Int(EthMaxTxFetchResponseRateFlag.Name)
+	}
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
 
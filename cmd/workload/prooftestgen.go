@@ -103,7 +103,10 @@ func genAccountProof(cli *client, startBlock uint64, endBlock uint64, number int
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	signer := types.LatestSignerForChainID(chainID)
+	signer, err := types.LatestSignerForChainIDSafe(chainID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	for {
 		if nAccounts >= number {
@@ -47,6 +47,15 @@ func CopyBytes(b []byte) (copiedBytes []byte) {
 	return
 }
 
+// CopyBytesFixed returns a copy of the provided bytes of exactly the given length,
+// zero padded on the right if shorter, or truncated on the right if longer. The
+// result is never nil.
+func CopyBytesFixed(b []byte, length int) []byte {
+	copiedBytes := make([]byte, length)
+	copy(copiedBytes, b)
+	return copiedBytes
+}
+
 // has0xPrefix validates str begins with '0x' or '0X'.
 func has0xPrefix(str string) bool {
 	return len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X')
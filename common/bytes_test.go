@@ -34,6 +34,25 @@ func TestCopyBytes(t *testing.T) {
 	}
 }
 
+func TestCopyBytesFixed(t *testing.T) {
+	input := []byte{1, 2, 3, 4}
+
+	if r := CopyBytesFixed(input, 6); !bytes.Equal(r, []byte{1, 2, 3, 4, 0, 0}) {
+		t.Fatalf("CopyBytesFixed(%v, 6) == %v", input, r)
+	}
+	if r := CopyBytesFixed(input, 2); !bytes.Equal(r, []byte{1, 2}) {
+		t.Fatalf("CopyBytesFixed(%v, 2) == %v", input, r)
+	}
+	if r := CopyBytesFixed(nil, 2); !bytes.Equal(r, []byte{0, 0}) {
+		t.Fatalf("CopyBytesFixed(nil, 2) == %v", r)
+	}
+	v := CopyBytesFixed(input, 4)
+	v[0] = 99
+	if bytes.Equal(v, input) {
+		t.Fatal("result is not a copy")
+	}
+}
+
 func TestLeftPadBytes(t *testing.T) {
 	val := []byte{1, 2, 3, 4}
 	padded := []byte{0, 0, 0, 0, 1, 2, 3, 4}
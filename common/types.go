@@ -19,6 +19,7 @@ package common
 import (
 	"bytes"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -85,6 +86,13 @@ func (h Hash) Cmp(other Hash) int {
 	return bytes.Compare(h[:], other[:])
 }
 
+// IsZero returns whether the hash is all zeroes. It folds the hash into four
+// words and checks them at once, instead of comparing it byte by byte.
+func (h Hash) IsZero() bool {
+	return binary.LittleEndian.Uint64(h[0:])|binary.LittleEndian.Uint64(h[8:])|
+		binary.LittleEndian.Uint64(h[16:])|binary.LittleEndian.Uint64(h[24:]) == 0
+}
+
 // Bytes gets the byte representation of the underlying hash.
 func (h Hash) Bytes() []byte { return h[:] }
 
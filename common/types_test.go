@@ -156,6 +156,41 @@ func BenchmarkAddressHex(b *testing.B) {
 	}
 }
 
+func TestHashIsZero(t *testing.T) {
+	if !(Hash{}).IsZero() {
+		t.Error("empty hash reported non-zero")
+	}
+	for i := 0; i < HashLength; i++ {
+		var h Hash
+		h[i] = 0x80
+		if h.IsZero() {
+			t.Errorf("hash with byte %d set reported zero", i)
+		}
+	}
+}
+
+var hashIsZeroSink bool
+
+func BenchmarkHashIsZero(b *testing.B) {
+	h := HexToHash("0x00000000000000000000000000000000000000000000000000000000000001")
+
+	b.Run("IsZero", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			hashIsZeroSink = h.IsZero()
+		}
+	})
+	b.Run("Equal", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			hashIsZeroSink = h == Hash{}
+		}
+	})
+	b.Run("BytesEqual", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			hashIsZeroSink = bytes.Equal(h[:], make([]byte, HashLength))
+		}
+	})
+}
+
 // Test checks if the customized json marshaller of MixedcaseAddress object
 // is invoked correctly. In golang the struct pointer will inherit the
 // non-pointer receiver methods, the reverse is not true. In the case of
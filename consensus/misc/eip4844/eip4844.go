@@ -26,68 +26,8 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-var (
-	minBlobGasPrice = big.NewInt(params.BlobTxMinBlobGasprice)
-)
-
-// BlobConfig contains the parameters for blob-related formulas.
-// These can be adjusted in a fork.
-type BlobConfig struct {
-	Target         int
-	Max            int
-	UpdateFraction uint64
-}
-
-func (bc *BlobConfig) maxBlobGas() uint64 {
-	return uint64(bc.Max) * params.BlobTxBlobGasPerBlob
-}
-
-// blobBaseFee computes the blob fee.
-func (bc *BlobConfig) blobBaseFee(excessBlobGas uint64) *big.Int {
-	return fakeExponential(minBlobGasPrice, new(big.Int).SetUint64(excessBlobGas), new(big.Int).SetUint64(bc.UpdateFraction))
-}
-
-// blobPrice returns the price of one blob in Wei.
-func (bc *BlobConfig) blobPrice(excessBlobGas uint64) *big.Int {
-	f := bc.blobBaseFee(excessBlobGas)
-	return new(big.Int).Mul(f, big.NewInt(params.BlobTxBlobGasPerBlob))
-}
-
-func latestBlobConfig(cfg *params.ChainConfig, time uint64) *BlobConfig {
-	if cfg.BlobScheduleConfig == nil {
-		return nil
-	}
-	var (
-		london = cfg.LondonBlock
-		s      = cfg.BlobScheduleConfig
-		bc     *params.BlobConfig
-	)
-	switch {
-	case cfg.IsBPO5(london, time) && s.BPO5 != nil:
-		bc = s.BPO5
-	case cfg.IsBPO4(london, time) && s.BPO4 != nil:
-		bc = s.BPO4
-	case cfg.IsBPO3(london, time) && s.BPO3 != nil:
-		bc = s.BPO3
-	case cfg.IsBPO2(london, time) && s.BPO2 != nil:
-		bc = s.BPO2
-	case cfg.IsBPO1(london, time) && s.BPO1 != nil:
-		bc = s.BPO1
-	case cfg.IsOsaka(london, time) && s.Osaka != nil:
-		bc = s.Osaka
-	case cfg.IsPrague(london, time) && s.Prague != nil:
-		bc = s.Prague
-	case cfg.IsCancun(london, time) && s.Cancun != nil:
-		bc = s.Cancun
-	default:
-		return nil
-	}
-
-	return &BlobConfig{
-		Target:         bc.Target,
-		Max:            bc.Max,
-		UpdateFraction: bc.UpdateFraction,
-	}
+func latestBlobConfig(cfg *params.ChainConfig, time uint64) *params.BlobConfig {
+	return cfg.ActiveBlobConfig(time)
 }
 
 // VerifyEIP4844Header verifies the presence of the excessBlobGas field and that
@@ -111,8 +51,8 @@ func VerifyEIP4844Header(config *params.ChainConfig, parent, header *types.Heade
 	}
 
 	// Verify that the blob gas used remains within reasonable limits.
-	if *header.BlobGasUsed > bcfg.maxBlobGas() {
-		return fmt.Errorf("blob gas used %d exceeds maximum allowance %d", *header.BlobGasUsed, bcfg.maxBlobGas())
+	if *header.BlobGasUsed > bcfg.BlobGasMax() {
+		return fmt.Errorf("blob gas used %d exceeds maximum allowance %d", *header.BlobGasUsed, bcfg.BlobGasMax())
 	}
 	if *header.BlobGasUsed%params.BlobTxBlobGasPerBlob != 0 {
 		return fmt.Errorf("blob gas used %d not a multiple of blob gas per blob %d", *header.BlobGasUsed, params.BlobTxBlobGasPerBlob)
@@ -131,10 +71,7 @@ func VerifyEIP4844Header(config *params.ChainConfig, parent, header *types.Heade
 func CalcExcessBlobGas(config *params.ChainConfig, parent *types.Header, headTimestamp uint64) uint64 {
 	isOsaka := config.IsOsaka(config.LondonBlock, headTimestamp)
 	bcfg := latestBlobConfig(config, headTimestamp)
-	return calcExcessBlobGas(isOsaka, bcfg, parent)
-}
 
-func calcExcessBlobGas(isOsaka bool, bcfg *BlobConfig, parent *types.Header) uint64 {
 	var parentExcessBlobGas, parentBlobGasUsed uint64
 	if parent.ExcessBlobGas != nil {
 		parentExcessBlobGas = *parent.ExcessBlobGas
@@ -143,7 +80,7 @@ func calcExcessBlobGas(isOsaka bool, bcfg *BlobConfig, parent *types.Header) uin
 
 	var (
 		excessBlobGas = parentExcessBlobGas + parentBlobGasUsed
-		targetGas     = uint64(bcfg.Target) * params.BlobTxBlobGasPerBlob
+		targetGas     = bcfg.BlobGasTarget()
 	)
 	if excessBlobGas < targetGas {
 		return 0
@@ -155,7 +92,8 @@ func calcExcessBlobGas(isOsaka bool, bcfg *BlobConfig, parent *types.Header) uin
 		var (
 			baseCost     = big.NewInt(params.BlobBaseCost)
 			reservePrice = baseCost.Mul(baseCost, parent.BaseFee)
-			blobPrice    = bcfg.blobPrice(parentExcessBlobGas)
+			blobFee      = params.BlobGasPrice(parentExcessBlobGas, config, headTimestamp)
+			blobPrice    = blobFee.Mul(blobFee, big.NewInt(params.BlobTxBlobGasPerBlob))
 		)
 		if reservePrice.Cmp(blobPrice) > 0 {
 			scaledExcess := parentBlobGasUsed * uint64(bcfg.Max-bcfg.Target) / uint64(bcfg.Max)
@@ -169,11 +107,11 @@ func calcExcessBlobGas(isOsaka bool, bcfg *BlobConfig, parent *types.Header) uin
 
 // CalcBlobFee calculates the blobfee from the header's excess blob gas field.
 func CalcBlobFee(config *params.ChainConfig, header *types.Header) *big.Int {
-	blobConfig := latestBlobConfig(config, header.Time)
-	if blobConfig == nil {
+	fee := params.BlobGasPrice(*header.ExcessBlobGas, config, header.Time)
+	if fee == nil {
 		panic("calculating blob fee on unsupported fork")
 	}
-	return blobConfig.blobBaseFee(*header.ExcessBlobGas)
+	return fee
 }
 
 // MaxBlobsPerBlock returns the max blobs per block for a block at the given timestamp.
@@ -187,7 +125,11 @@ func MaxBlobsPerBlock(cfg *params.ChainConfig, time uint64) int {
 
 // MaxBlobGasPerBlock returns the maximum blob gas that can be spent in a block at the given timestamp.
 func MaxBlobGasPerBlock(cfg *params.ChainConfig, time uint64) uint64 {
-	return uint64(MaxBlobsPerBlock(cfg, time)) * params.BlobTxBlobGasPerBlob
+	blobConfig := latestBlobConfig(cfg, time)
+	if blobConfig == nil {
+		return 0
+	}
+	return blobConfig.BlobGasMax()
 }
 
 // LatestMaxBlobsPerBlock returns the latest max blobs per block defined by the
@@ -208,20 +150,3 @@ func TargetBlobsPerBlock(cfg *params.ChainConfig, time uint64) int {
 	}
 	return blobConfig.Target
 }
-
-// fakeExponential approximates factor * e ** (numerator / denominator) using
-// Taylor expansion.
-func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
-	var (
-		output = new(big.Int)
-		accum  = new(big.Int).Mul(factor, denominator)
-	)
-	for i := 1; accum.Sign() > 0; i++ {
-		output.Add(output, accum)
-
-		accum.Mul(accum, numerator)
-		accum.Div(accum, denominator)
-		accum.Div(accum, big.NewInt(int64(i)))
-	}
-	return output.Div(output, denominator)
-}
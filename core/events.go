@@ -17,12 +17,17 @@
 package core
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // NewTxsEvent is posted when a batch of transactions enter the transaction pool.
 type NewTxsEvent struct{ Txs []*types.Transaction }
 
+// EvictedTxsEvent is posted when a batch of transactions is evicted from the
+// transaction pool due to a change in its limits.
+type EvictedTxsEvent struct{ Hashes []common.Hash }
+
 // RemovedLogsEvent is posted when a reorg happens
 type RemovedLogsEvent struct{ Logs []*types.Log }
 
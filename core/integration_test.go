@@ -0,0 +1,153 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests the complete lifecycle of a blob transaction: signing it, fetching it
+// from a peer into the blob pool (running the KZG validation), including it in
+// a block, processing its state transition and retrieving its sidecar.
+func TestBlobTxLifecycle(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = params.CancunTestChainConfig
+		engine = beacon.New(ethash.NewFaker())
+		gspec  = &core.Genesis{
+			Config:     config,
+			Alloc:      types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: common.Big0,
+		}
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Sign a blob transaction carrying a single random blob
+	blob, _ := kzg4844.NewRandomBlob()
+	sidecar := types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{blob}, nil, nil)
+	if err := sidecar.FillCommitmentsAndProofs(); err != nil {
+		t.Fatalf("failed to compute sidecar: %v", err)
+	}
+	tx := types.MustSignNewTx(key, types.LatestSigner(config), &types.BlobTx{
+		ChainID:    uint256.MustFromBig(config.ChainID),
+		GasTipCap:  uint256.NewInt(params.GWei),
+		GasFeeCap:  uint256.NewInt(100 * params.GWei),
+		Gas:        params.TxGas,
+		To:         common.Address{0x01},
+		Value:      uint256.NewInt(1),
+		BlobFeeCap: uint256.NewInt(100 * params.GWei),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	// Announce the transaction to the fetcher, which retrieves it from the peer
+	// and hands it to the blob pool for validation
+	pool := blobpool.New(blobpool.Config{Datadir: t.TempDir()}, chain, func(common.Address) bool { return false })
+	if err := pool.Init(1, chain.CurrentBlock(), txpool.NewReservationTracker().NewHandle(0)); err != nil {
+		t.Fatalf("failed to initialize blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	var f *fetcher.TxFetcher
+	f = fetcher.NewTxFetcher(
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error { return pool.Add(txs, true) },
+		func(peer string, hashes []common.Hash) error {
+			go f.Enqueue(peer, []*types.Transaction{tx}, true)
+			return nil
+		},
+		func(peer string) { t.Errorf("peer %s dropped", peer) },
+	)
+	f.Start()
+	defer f.Stop()
+
+	if err := f.Notify("peer", []byte{tx.Type()}, []uint32{uint32(tx.Size())}, []common.Hash{tx.Hash()}); err != nil {
+		t.Fatalf("failed to announce transaction: %v", err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); !pool.Has(tx.Hash()); {
+		if time.Now().After(deadline) {
+			t.Fatal("transaction not added to the blob pool")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Retrieve the sidecar from the pool's store
+	pooled := pool.Get(tx.Hash())
+	if pooled == nil || pooled.BlobTxSidecar() == nil {
+		t.Fatal("pooled transaction missing sidecar")
+	}
+	if err := pooled.BlobTxSidecar().Verify(tx.BlobHashes()); err != nil {
+		t.Fatalf("pooled sidecar invalid: %v", err)
+	}
+	blobs, _, proofs, err := pool.GetBlobs(tx.BlobHashes(), types.BlobSidecarVersion0)
+	if err != nil {
+		t.Fatalf("failed to retrieve blobs: %v", err)
+	}
+	if blobs[0] == nil || *blobs[0] != blob || len(proofs[0]) != 1 || proofs[0][0] != sidecar.Proofs[0] {
+		t.Fatal("retrieved blob mismatch")
+	}
+	// Include the transaction in a block and process it
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *core.BlockGen) {
+		b.AddTx(pooled.WithoutBlobTxSidecar())
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	head := chain.CurrentBlock()
+	if head.Hash() != blocks[0].Hash() || head.BlobGasUsed == nil || *head.BlobGasUsed != params.BlobTxBlobGasPerBlob {
+		t.Fatalf("head block mismatch: have %d (blob gas %v)", head.Number, head.BlobGasUsed)
+	}
+	receipts := chain.GetReceiptsByHash(head.Hash())
+	if len(receipts) != 1 || receipts[0].Status != types.ReceiptStatusSuccessful || receipts[0].BlobGasUsed != params.BlobTxBlobGasPerBlob {
+		t.Fatalf("receipt mismatch: %+v", receipts)
+	}
+	state, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve head state: %v", err)
+	}
+	if nonce := state.GetNonce(addr); nonce != 1 {
+		t.Errorf("sender nonce mismatch: have %d, want 1", nonce)
+	}
+	if balance := state.GetBalance(common.Address{0x01}); !balance.Eq(uint256.NewInt(1)) {
+		t.Errorf("recipient balance mismatch: have %v, want 1", balance)
+	}
+	// Ensure the pool drops the included transaction on the new head
+	pool.Reset(chain.Genesis().Header(), head)
+	if pool.Has(tx.Hash()) {
+		t.Error("included transaction still pooled")
+	}
+}
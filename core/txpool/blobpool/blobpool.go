@@ -25,6 +25,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -330,13 +331,17 @@ type BlobPool struct {
 	stored uint64         // Useful data size of all transactions on disk
 	limbo  *limbo         // Persistent data store for the non-finalized blobs
 
-	signer types.Signer // Transaction signer to use for sender recovery
-	chain  BlockChain   // Chain object to access the state through
+	signer     types.Signer           // Transaction signer to use for sender recovery
+	chain      BlockChain             // Chain object to access the state through
+	proofCache *txpool.BlobProofCache // Cache of already verified blob proofs (nil = disabled)
+	kzgWorkers *txpool.KZGWorkers     // Limiter of the concurrent KZG proof verifications
 
 	head   atomic.Pointer[types.Header] // Current head of the chain
 	state  *state.StateDB               // Current state at the head of the chain
 	gasTip atomic.Pointer[uint256.Int]  // Currently accepted minimum gas tip
 
+	maxTxBlobs atomic.Int64 // Currently accepted maximum number of blobs per transaction
+
 	lookup *lookup                          // Lookup table mapping blobs to txs and txs to billy entries
 	index  map[common.Address][]*blobTxMeta // Blob transactions grouped by accounts, sorted by nonce
 	spent  map[common.Address]*uint256.Int  // Expenditure tracking for individual accounts
@@ -344,6 +349,7 @@ type BlobPool struct {
 
 	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
 	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
+	evictFeed    event.Feed // Event feed to send out tx hashes evicted due to limit changes
 
 	lock sync.RWMutex // Mutex protecting the pool during reorg handling
 }
@@ -355,8 +361,14 @@ func New(config Config, chain BlockChain, hasPendingAuth func(common.Address) bo
 	config = (&config).sanitize()
 
 	// Create the transaction pool with its initial settings
-	return &BlobPool{
+	var proofCache *txpool.BlobProofCache
+	if config.ProofCacheSize > 0 {
+		proofCache = txpool.NewBlobProofCache(config.ProofCacheSize)
+	}
+	pool := &BlobPool{
 		config:         config,
+		proofCache:     proofCache,
+		kzgWorkers:     txpool.NewKZGWorkers(config.KZGWorkers),
 		hasPendingAuth: hasPendingAuth,
 		signer:         types.LatestSigner(chain.Config()),
 		chain:          chain,
@@ -364,6 +376,8 @@ func New(config Config, chain BlockChain, hasPendingAuth func(common.Address) bo
 		index:          make(map[common.Address][]*blobTxMeta),
 		spent:          make(map[common.Address]*uint256.Int),
 	}
+	pool.maxTxBlobs.Store(maxBlobsPerTx)
+	return pool
 }
 
 // Filter returns whether the given transaction can be consumed by the blob pool.
@@ -1137,17 +1151,89 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 	p.updateStorageMetrics()
 }
 
+// SetMaxBlobs updates the maximum number of blobs a single transaction may carry
+// to be accepted into the pool, e.g. when a fork changes the blob limits. Pooled
+// transactions over the new limit are evicted along with all the subsequent
+// nonces of their senders (youngest first), and announced on the eviction feed.
+func (p *BlobPool) SetMaxBlobs(n int) {
+	if n < 1 || n > maxBlobsPerTx {
+		limit := min(max(n, 1), maxBlobsPerTx)
+		log.Warn("Sanitizing invalid blobpool blob limit", "provided", n, "updated", limit)
+		n = limit
+	}
+	evicted := p.setMaxBlobs(n)
+	if len(evicted) > 0 {
+		p.evictFeed.Send(core.EvictedTxsEvent{Hashes: evicted})
+	}
+}
+
+// setMaxBlobs updates the per-transaction blob limit and evicts any offending
+// transactions, returning the hashes of the evicted ones.
+func (p *BlobPool) setMaxBlobs(n int) []common.Hash {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// If the limit was raised, there's nothing to evict
+	old := p.maxTxBlobs.Swap(int64(n))
+	log.Debug("Blobpool blob limit updated", "limit", n)
+	if int64(n) >= old {
+		return nil
+	}
+	var evicted []common.Hash
+	for addr, txs := range p.index {
+		i := slices.IndexFunc(txs, func(tx *blobTxMeta) bool { return len(tx.vhashes) > n })
+		if i < 0 {
+			continue
+		}
+		// Drop the offending transaction and everything afterwards, no gaps
+		// allowed, starting from the youngest
+		nonces := make([]uint64, 0, len(txs)-i)
+		for j := len(txs) - 1; j >= i; j-- {
+			tx := txs[j]
+			nonces = append(nonces, tx.nonce)
+			evicted = append(evicted, tx.hash)
+
+			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
+			p.stored -= uint64(tx.storageSize)
+			p.lookup.untrack(tx)
+			txs[j] = nil
+
+			if err := p.store.Delete(tx.id); err != nil {
+				log.Error("Failed to delete evicted transaction", "id", tx.id, "err", err)
+			}
+		}
+		// Clear out the dropped transactions from the index
+		if i > 0 {
+			p.index[addr] = txs[:i]
+			heap.Fix(p.evict, p.evict.index[addr])
+		} else {
+			delete(p.index, addr)
+			delete(p.spent, addr)
+
+			heap.Remove(p.evict, p.evict.index[addr])
+			p.reserver.Release(addr)
+		}
+		log.Warn("Evicting blob transactions over the blob limit", "from", addr, "limit", n, "drop", nonces)
+		dropOverblobbedMeter.Mark(int64(len(nonces)))
+	}
+	p.updateStorageMetrics()
+	return evicted
+}
+
 // ValidateTxBasics checks whether a transaction is valid according to the consensus
 // rules, but does not check state-dependent validation such as sufficient balance.
 // This check is meant as an early check which only needs to be performed once,
 // and does not require the pool mutex to be held.
 func (p *BlobPool) ValidateTxBasics(tx *types.Transaction) error {
 	opts := &txpool.ValidationOptions{
-		Config:       p.chain.Config(),
-		Accept:       1 << types.BlobTxType,
-		MaxSize:      txMaxSize,
-		MinTip:       p.gasTip.Load().ToBig(),
-		MaxBlobCount: maxBlobsPerTx,
+		Config:         p.chain.Config(),
+		Accept:         1 << types.BlobTxType,
+		MaxSize:        txMaxSize,
+		MinTip:         p.gasTip.Load().ToBig(),
+		MaxBlobCount:   int(p.maxTxBlobs.Load()),
+		GasCapMultiple: 1,
+		ProofCache:     p.proofCache,
+		KZGWorkers:     p.kzgWorkers,
 	}
 	return txpool.ValidateTransaction(tx, p.head.Load(), p.signer, opts)
 }
@@ -1191,9 +1277,25 @@ func (p *BlobPool) checkDelegationLimit(tx *types.Transaction) error {
 // This function assumes the static validation has been performed already and
 // only runs the stateful checks with lock protection.
 func (p *BlobPool) validateTx(tx *types.Transaction) error {
-	// Ensure the transaction adheres to the stateful pool filters (nonce, balance)
+	// Ensure the transaction adheres to the stateful pool filters (nonce, balance).
+	// The balance is checked against the cost at the pending fees, except for
+	// blob fees above the transaction's cap: the pool holds such transactions
+	// until the blob fee drops, so they never pay more than their cap.
+	var (
+		head    = p.head.Load()
+		basefee = eip1559.CalcBaseFee(p.chain.Config(), head)
+		blobfee = big.NewInt(params.BlobTxMinBlobGasprice)
+	)
+	if head.ExcessBlobGas != nil {
+		blobfee = eip4844.CalcBlobFee(p.chain.Config(), head)
+	}
+	if blobfee.Cmp(tx.BlobGasFeeCap()) > 0 {
+		blobfee = tx.BlobGasFeeCap()
+	}
 	stateOpts := &txpool.ValidationOptionsWithState{
-		State: p.state,
+		State:       p.state,
+		BaseFee:     basefee,
+		BlobBaseFee: blobfee,
 
 		FirstNonceGap: func(addr common.Address) uint64 {
 			// Nonce gaps are not permitted in the blob pool, the first gap will
@@ -1459,6 +1561,11 @@ func (p *BlobPool) Add(txs []*types.Transaction, sync bool) []error {
 		adds = make([]*types.Transaction, 0, len(txs))
 	)
 	for i, tx := range txs {
+		// Reject duplicates before running the expensive blob validations
+		if p.Has(tx.Hash()) {
+			errs[i] = txpool.ErrAlreadyKnown
+			continue
+		}
 		if errs[i] = p.ValidateTxBasics(tx); errs[i] != nil {
 			continue
 		}
@@ -1526,7 +1633,10 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 			// Note, `err` here is the named error return, which will be initialized
 			// by a return statement before running deferred methods. Take care with
 			// removing or subscoping err as it will break this clause.
-			if err != nil {
+			//
+			// A transaction evicted due to the pool being full already released the
+			// reservation when its account got dropped.
+			if err != nil && !errors.Is(err, txpool.ErrBlobPoolFull) {
 				p.reserver.Release(from)
 			}
 		}()
@@ -1636,6 +1746,12 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 	}
 	p.updateStorageMetrics()
 
+	// If the new transaction was the cheapest one and got evicted to make room,
+	// report it instead of silently discarding it
+	if !p.lookup.exists(tx.Hash()) {
+		addFullMeter.Mark(1)
+		return txpool.ErrBlobPoolFull
+	}
 	addValidMeter.Mark(1)
 	return nil
 }
@@ -1769,6 +1885,77 @@ func (p *BlobPool) Pending(filter txpool.PendingFilter) map[common.Address][]*tx
 	return pending
 }
 
+// Peek returns the most profitable blob transactions includable in the next
+// block, up to a combined maxBlobs blobs, without removing them from the pool.
+// Transactions are picked greedily by their effective tip, honouring the nonce
+// ordering within each account, so building a block from them is speculative.
+func (p *BlobPool) Peek(maxBlobs int) []*types.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var (
+		head    = p.head.Load()
+		basefee = uint256.MustFromBig(eip1559.CalcBaseFee(p.chain.Config(), head))
+		blobfee = uint256.NewInt(params.BlobTxMinBlobGasprice)
+	)
+	if head.ExcessBlobGas != nil {
+		blobfee = uint256.MustFromBig(eip4844.CalcBlobFee(p.chain.Config(), head))
+	}
+	// Track the next nonce-ordered candidate of every account, dropping accounts
+	// from the set once their next transaction cannot be included
+	next := make(map[common.Address]int, len(p.index))
+	for addr := range p.index {
+		next[addr] = 0
+	}
+	var (
+		txs   []*types.Transaction
+		blobs int
+	)
+	for len(next) > 0 {
+		var (
+			best    common.Address
+			bestTip *uint256.Int
+		)
+		for addr, i := range next {
+			meta := p.index[addr][i]
+			if meta.execFeeCap.Lt(basefee) || meta.blobFeeCap.Lt(blobfee) || blobs+len(meta.vhashes) > maxBlobs {
+				delete(next, addr)
+				continue
+			}
+			tip := new(uint256.Int).Sub(meta.execFeeCap, basefee)
+			if tip.Gt(meta.execTipCap) {
+				tip = meta.execTipCap
+			}
+			if bestTip == nil || tip.Gt(bestTip) || (tip.Eq(bestTip) && addr.Cmp(best) < 0) {
+				best, bestTip = addr, tip
+			}
+		}
+		if bestTip == nil {
+			break
+		}
+		meta := p.index[best][next[best]]
+		data, err := p.store.Get(meta.id)
+		if err != nil {
+			log.Error("Tracked blob transaction missing from store", "hash", meta.hash, "id", meta.id, "err", err)
+			delete(next, best)
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(data, tx); err != nil {
+			log.Error("Blobs corrupted for traced transaction", "hash", meta.hash, "id", meta.id, "err", err)
+			delete(next, best)
+			continue
+		}
+		txs = append(txs, tx)
+		blobs += len(meta.vhashes)
+
+		if next[best]++; next[best] == len(p.index[best]) {
+			delete(next, best)
+		}
+	}
+	return txs
+}
+
 // updateStorageMetrics retrieves a bunch of stats from the data store and pushes
 // them out as metrics.
 func (p *BlobPool) updateStorageMetrics() {
@@ -1855,6 +2042,12 @@ func (p *BlobPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool
 	}
 }
 
+// SubscribeEvictions registers a subscription for the hashes of transactions
+// evicted from the pool due to a change in its limits (e.g. SetMaxBlobs).
+func (p *BlobPool) SubscribeEvictions(ch chan<- core.EvictedTxsEvent) event.Subscription {
+	return p.evictFeed.Subscribe(ch)
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *BlobPool) Nonce(addr common.Address) uint64 {
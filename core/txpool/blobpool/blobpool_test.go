@@ -1335,6 +1335,268 @@ func TestBlobCountLimit(t *testing.T) {
 	pool.Close()
 }
 
+// Tests that adding a transaction into a full pool, which gets evicted straight
+// away as the cheapest one, is reported as such instead of silently dropped.
+func TestAddFull(t *testing.T) {
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		key3, _ = crypto.GenerateKey()
+
+		addr1 = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2 = crypto.PubkeyToAddress(key2.PublicKey)
+		addr3 = crypto.PubkeyToAddress(key3.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr1, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr2, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr3, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	// Cap the pool to 2 blob transactions
+	reserver := newReserver()
+	pool := New(Config{Datadir: t.TempDir(), Datacap: 2 * (txAvgSize + blobSize + uint64(txBlobOverhead))}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), reserver); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	var (
+		tx1 = makeTx(0, 1, 1000, 100, key1)
+		tx2 = makeTx(0, 1, 1500, 110, key2)
+		tx3 = makeTx(0, 1, 800, 70, key3)
+	)
+	errs := pool.Add([]*types.Transaction{tx1, tx2, tx3}, true)
+	for i, err := range errs[:2] {
+		if err != nil {
+			t.Errorf("tx %d: failed to add: %v", i, err)
+		}
+	}
+	if !errors.Is(errs[2], txpool.ErrBlobPoolFull) {
+		t.Errorf("cheapest tx error mismatch: have %v, want %v", errs[2], txpool.ErrBlobPoolFull)
+	}
+	if pool.Has(tx3.Hash()) {
+		t.Error("evicted transaction still in pool")
+	}
+	if reserver.Has(addr3) {
+		t.Error("evicted account still reserved")
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that re-adding an already pooled transaction is rejected as known
+// before any of the blob validations (and the KZG proof checks) are run.
+func TestAddKnown(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: t.TempDir()}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	tx := makeTx(0, 1, 1000, 100, key)
+	if errs := pool.Add([]*types.Transaction{tx}, true); errs[0] != nil {
+		t.Fatalf("failed to add transaction: %v", errs[0])
+	}
+	// Corrupt the proofs of the re-announced copy. The sidecar is not part of
+	// the transaction hash, so if the pool ran the KZG checks it would report
+	// an invalid proof instead of a known transaction.
+	sidecar := tx.BlobTxSidecar().Copy()
+	sidecar.Proofs[0][0] ^= 0xff
+	dup := tx.WithBlobTxSidecar(sidecar)
+
+	errs := pool.Add([]*types.Transaction{dup}, true)
+	if !errors.Is(errs[0], txpool.ErrAlreadyKnown) {
+		t.Errorf("duplicate tx error mismatch: have %v, want %v", errs[0], txpool.ErrAlreadyKnown)
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that lowering the blob limit evicts the offending transactions along
+// with all their subsequent nonces, and rejects new ones over the limit.
+func TestSetMaxBlobs(t *testing.T) {
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		key3, _ = crypto.GenerateKey()
+
+		addr1 = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2 = crypto.PubkeyToAddress(key2.PublicKey)
+		addr3 = crypto.PubkeyToAddress(key3.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr1, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr2, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr3, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: t.TempDir()}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	var (
+		small1 = makeMultiBlobTx(0, 1, 1000, 100, 1, 0, key1, types.BlobSidecarVersion0)
+		large1 = makeMultiBlobTx(1, 1, 1000, 100, 3, 1, key1, types.BlobSidecarVersion0)
+		after1 = makeMultiBlobTx(2, 1, 1000, 100, 1, 4, key1, types.BlobSidecarVersion0)
+		large2 = makeMultiBlobTx(0, 1, 1000, 100, 3, 5, key2, types.BlobSidecarVersion0)
+	)
+	for i, err := range pool.Add([]*types.Transaction{small1, large1, after1, large2}, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	events := make(chan core.EvictedTxsEvent, 1)
+	sub := pool.SubscribeEvictions(events)
+	defer sub.Unsubscribe()
+
+	// Lower the limit and ensure the offending transactions are evicted
+	pool.SetMaxBlobs(2)
+
+	select {
+	case ev := <-events:
+		slices.SortFunc(ev.Hashes, common.Hash.Cmp)
+		want := []common.Hash{large1.Hash(), after1.Hash(), large2.Hash()}
+		slices.SortFunc(want, common.Hash.Cmp)
+		if !slices.Equal(ev.Hashes, want) {
+			t.Errorf("evicted hashes mismatch: have %v, want %v", ev.Hashes, want)
+		}
+	default:
+		t.Fatalf("no eviction event")
+	}
+	if !pool.Has(small1.Hash()) {
+		t.Errorf("transaction within the limit evicted")
+	}
+	for _, tx := range []*types.Transaction{large1, after1, large2} {
+		if pool.Has(tx.Hash()) {
+			t.Errorf("transaction %x not evicted", tx.Hash())
+		}
+	}
+	verifyPoolInternals(t, pool)
+
+	// New transactions over the limit should be rejected, within accepted
+	var (
+		large3 = makeMultiBlobTx(0, 1, 1000, 100, 3, 8, key3, types.BlobSidecarVersion0)
+		small3 = makeMultiBlobTx(0, 1, 1000, 100, 2, 8, key3, types.BlobSidecarVersion0)
+	)
+	if err := pool.Add([]*types.Transaction{large3}, true)[0]; !errors.Is(err, txpool.ErrTxBlobLimitExceeded) {
+		t.Errorf("transaction over the limit error mismatch: have %v, want %v", err, txpool.ErrTxBlobLimitExceeded)
+	}
+	if err := pool.Add([]*types.Transaction{small3}, true)[0]; err != nil {
+		t.Errorf("transaction within the limit rejected: %v", err)
+	}
+	// Raising the limit should not evict anything
+	pool.SetMaxBlobs(maxBlobsPerTx)
+	select {
+	case ev := <-events:
+		t.Errorf("unexpected eviction event: %v", ev.Hashes)
+	default:
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that peeking into the pool returns the most profitable includable
+// transactions in nonce order, within the blob limit, without altering the pool.
+func TestPeek(t *testing.T) {
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		key3, _ = crypto.GenerateKey()
+
+		addr1 = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2 = crypto.PubkeyToAddress(key2.PublicKey)
+		addr3 = crypto.PubkeyToAddress(key3.PublicKey)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr1, uint256.NewInt(10_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr2, uint256.NewInt(10_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr3, uint256.NewInt(10_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: t.TempDir()}, chain, nil)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	var (
+		cheap1 = makeMultiBlobTx(0, 10, 10000, 1000, 1, 0, key1, types.BlobSidecarVersion0)
+		rich1  = makeMultiBlobTx(1, 100, 10000, 1000, 2, 1, key1, types.BlobSidecarVersion0)
+		mid2   = makeMultiBlobTx(0, 50, 10000, 1000, 1, 3, key2, types.BlobSidecarVersion0)
+		under3 = makeMultiBlobTx(0, 1000, 1000, 1000, 1, 4, key3, types.BlobSidecarVersion0)
+	)
+	for i, err := range pool.Add([]*types.Transaction{cheap1, rich1, mid2, under3}, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	tests := []struct {
+		maxBlobs int
+		want     []*types.Transaction
+	}{
+		{0, nil},
+		{1, []*types.Transaction{mid2}},
+		{2, []*types.Transaction{mid2, cheap1}},
+		{3, []*types.Transaction{mid2, cheap1}},
+		{4, []*types.Transaction{mid2, cheap1, rich1}},
+		{100, []*types.Transaction{mid2, cheap1, rich1}},
+	}
+	for i, tt := range tests {
+		have := pool.Peek(tt.maxBlobs)
+		if len(have) != len(tt.want) {
+			t.Errorf("test %d: transaction count mismatch: have %d, want %d", i, len(have), len(tt.want))
+			continue
+		}
+		for j := range have {
+			if have[j].Hash() != tt.want[j].Hash() {
+				t.Errorf("test %d, tx %d: hash mismatch: have %x, want %x", i, j, have[j].Hash(), tt.want[j].Hash())
+			}
+		}
+	}
+	// Ensure peeking did not remove anything from the pool
+	if pending, _ := pool.Stats(); pending != 4 {
+		t.Errorf("pending transaction count mismatch: have %d, want 4", pending)
+	}
+	for _, tx := range []*types.Transaction{cheap1, rich1, mid2, under3} {
+		if !pool.Has(tx.Hash()) {
+			t.Errorf("transaction %x removed from the pool", tx.Hash())
+		}
+	}
+	verifyPoolInternals(t, pool)
+}
+
 // Tests that adding transaction will correctly store it in the persistent store
 // and update all the indices.
 //
@@ -17,6 +17,8 @@
 package blobpool
 
 import (
+	"runtime"
+
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -25,6 +27,9 @@ type Config struct {
 	Datadir   string // Data directory containing the currently executable blobs
 	Datacap   uint64 // Soft-cap of database storage (hard cap is larger due to overhead)
 	PriceBump uint64 // Minimum price bump percentage to replace an already existing nonce
+
+	ProofCacheSize int // Number of verified blob proofs to remember (0 = disabled)
+	KZGWorkers     int // Number of KZG proof verifications to run concurrently
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -32,6 +37,9 @@ var DefaultConfig = Config{
 	Datadir:   "blobpool",
 	Datacap:   10 * 1024 * 1024 * 1024 / 4, // TODO(karalabe): /4 handicap for rollout, gradually bump back up to 10GB
 	PriceBump: 100,                         // either have patience or be aggressive, no mushy ground
+
+	ProofCacheSize: 8192,
+	KZGWorkers:     runtime.NumCPU(),
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -46,5 +54,13 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid blobpool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
 		conf.PriceBump = DefaultConfig.PriceBump
 	}
+	if conf.ProofCacheSize < 0 {
+		log.Warn("Sanitizing invalid blobpool proof cache size", "provided", conf.ProofCacheSize, "updated", DefaultConfig.ProofCacheSize)
+		conf.ProofCacheSize = DefaultConfig.ProofCacheSize
+	}
+	if conf.KZGWorkers < 1 {
+		log.Warn("Sanitizing invalid blobpool KZG workers", "provided", conf.KZGWorkers, "updated", DefaultConfig.KZGWorkers)
+		conf.KZGWorkers = DefaultConfig.KZGWorkers
+	}
 	return conf
 }
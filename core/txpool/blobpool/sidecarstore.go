@@ -0,0 +1,140 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrSidecarNotFound is returned if a sidecar is requested from a store which
+// does not contain it.
+var ErrSidecarNotFound = errors.New("blob sidecar not found")
+
+// sidecarStorePrefix is the key prefix of the sidecars kept in a key-value store,
+// followed by the hash of the owner transaction.
+var sidecarStorePrefix = []byte("blobpool-sidecar-")
+
+// SidecarStore is a storage for blob sidecars indexed by the hash of the blob
+// transaction they belong to, allowing them to be retrieved after the owner
+// transaction left the pool (e.g. by archive nodes serving historical blocks).
+type SidecarStore interface {
+	// Get retrieves the sidecar of a transaction, or ErrSidecarNotFound.
+	Get(txHash common.Hash) (*types.BlobTxSidecar, error)
+
+	// Put stores the sidecar of a transaction, overwriting any previous one.
+	Put(txHash common.Hash, sidecar *types.BlobTxSidecar) error
+
+	// Delete removes the sidecar of a transaction, if it is stored.
+	Delete(txHash common.Hash) error
+}
+
+// memorySidecarStore is a SidecarStore keeping the sidecars in a map.
+type memorySidecarStore struct {
+	sidecars map[common.Hash]*types.BlobTxSidecar
+	lock     sync.RWMutex
+}
+
+// NewMemorySidecarStore creates an in-memory sidecar store.
+func NewMemorySidecarStore() SidecarStore {
+	return &memorySidecarStore{
+		sidecars: make(map[common.Hash]*types.BlobTxSidecar),
+	}
+}
+
+// Get implements SidecarStore, retrieving a sidecar from the map.
+func (s *memorySidecarStore) Get(txHash common.Hash) (*types.BlobTxSidecar, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	sidecar, ok := s.sidecars[txHash]
+	if !ok {
+		return nil, ErrSidecarNotFound
+	}
+	return sidecar, nil
+}
+
+// Put implements SidecarStore, inserting a sidecar into the map.
+func (s *memorySidecarStore) Put(txHash common.Hash, sidecar *types.BlobTxSidecar) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sidecars[txHash] = sidecar
+	return nil
+}
+
+// Delete implements SidecarStore, removing a sidecar from the map.
+func (s *memorySidecarStore) Delete(txHash common.Hash) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.sidecars, txHash)
+	return nil
+}
+
+// dbSidecarStore is a SidecarStore keeping RLP encoded sidecars in a key-value
+// database.
+type dbSidecarStore struct {
+	db ethdb.KeyValueStore
+}
+
+// NewDatabaseSidecarStore creates a sidecar store persisting into the given
+// key-value database.
+func NewDatabaseSidecarStore(db ethdb.KeyValueStore) SidecarStore {
+	return &dbSidecarStore{db: db}
+}
+
+// sidecarKey = sidecarStorePrefix + txHash
+func sidecarKey(txHash common.Hash) []byte {
+	return append(append([]byte{}, sidecarStorePrefix...), txHash.Bytes()...)
+}
+
+// Get implements SidecarStore, loading and decoding a sidecar from the database.
+func (s *dbSidecarStore) Get(txHash common.Hash) (*types.BlobTxSidecar, error) {
+	key := sidecarKey(txHash)
+	if ok, _ := s.db.Has(key); !ok {
+		return nil, ErrSidecarNotFound
+	}
+	blob, err := s.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	sidecar := new(types.BlobTxSidecar)
+	if err := rlp.DecodeBytes(blob, sidecar); err != nil {
+		return nil, err
+	}
+	return sidecar, nil
+}
+
+// Put implements SidecarStore, encoding and writing a sidecar into the database.
+func (s *dbSidecarStore) Put(txHash common.Hash, sidecar *types.BlobTxSidecar) error {
+	blob, err := rlp.EncodeToBytes(sidecar)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(sidecarKey(txHash), blob)
+}
+
+// Delete implements SidecarStore, deleting a sidecar from the database.
+func (s *dbSidecarStore) Delete(txHash common.Hash) error {
+	return s.db.Delete(sidecarKey(txHash))
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// Tests that the sidecar stores round trip sidecars and report missing ones.
+func TestSidecarStore(t *testing.T) {
+	tests := []struct {
+		name  string
+		store SidecarStore
+	}{
+		{"memory", NewMemorySidecarStore()},
+		{"database", NewDatabaseSidecarStore(memorydb.New())},
+	}
+	sidecars := []*types.BlobTxSidecar{
+		types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{{0x01}}, []kzg4844.Commitment{{0x02}}, []kzg4844.Proof{{0x03}}),
+		types.NewBlobTxSidecar(types.BlobSidecarVersion1, []kzg4844.Blob{{0x04}}, []kzg4844.Commitment{{0x05}}, make([]kzg4844.Proof, kzg4844.CellProofsPerBlob)),
+	}
+	for _, tt := range tests {
+		if _, err := tt.store.Get(common.Hash{0x01}); !errors.Is(err, ErrSidecarNotFound) {
+			t.Errorf("%s: missing sidecar error mismatch: have %v, want %v", tt.name, err, ErrSidecarNotFound)
+		}
+		for i, sidecar := range sidecars {
+			if err := tt.store.Put(common.Hash{byte(i)}, sidecar); err != nil {
+				t.Fatalf("%s: failed to store sidecar %d: %v", tt.name, i, err)
+			}
+		}
+		for i, sidecar := range sidecars {
+			have, err := tt.store.Get(common.Hash{byte(i)})
+			if err != nil {
+				t.Fatalf("%s: failed to retrieve sidecar %d: %v", tt.name, i, err)
+			}
+			if have.Version != sidecar.Version || !reflect.DeepEqual(have.Blobs, sidecar.Blobs) ||
+				!reflect.DeepEqual(have.Commitments, sidecar.Commitments) || !reflect.DeepEqual(have.Proofs, sidecar.Proofs) {
+				t.Errorf("%s: sidecar %d mismatch", tt.name, i)
+			}
+		}
+		if err := tt.store.Delete(common.Hash{0x00}); err != nil {
+			t.Fatalf("%s: failed to delete sidecar: %v", tt.name, err)
+		}
+		if _, err := tt.store.Get(common.Hash{0x00}); !errors.Is(err, ErrSidecarNotFound) {
+			t.Errorf("%s: deleted sidecar error mismatch: have %v, want %v", tt.name, err, ErrSidecarNotFound)
+		}
+		if _, err := tt.store.Get(common.Hash{0x01}); err != nil {
+			t.Errorf("%s: remaining sidecar lost: %v", tt.name, err)
+		}
+	}
+}
@@ -18,6 +18,9 @@ package txpool
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core"
 )
 
 var (
@@ -41,6 +44,10 @@ var (
 	// minimum configured for the transaction pool.
 	ErrTxGasPriceTooLow = errors.New("transaction gas price below minimum")
 
+	// ErrTxGasPriceTooHigh is returned if a transaction's gas fee cap is above
+	// the maximum configured for the transaction pool.
+	ErrTxGasPriceTooHigh = errors.New("transaction gas price above maximum")
+
 	// ErrAccountLimitExceeded is returned if a transaction would exceed the number
 	// allowed by a pool for a single account.
 	ErrAccountLimitExceeded = errors.New("account limit exceeded")
@@ -71,4 +78,95 @@ var (
 	// ErrInflightTxLimitReached is returned when the maximum number of in-flight
 	// transactions is reached for specific accounts.
 	ErrInflightTxLimitReached = errors.New("in-flight transaction limit reached for delegated accounts")
+
+	// ErrKZGTimeout is returned if the KZG proof verification of a blob transaction
+	// did not finish within the allowed time.
+	ErrKZGTimeout = errors.New("kzg proof verification timed out")
+
+	// ErrBlobTxNoContractCreation is returned if a blob transaction has no
+	// recipient, attempting to create a contract.
+	ErrBlobTxNoContractCreation = errors.New("blob transaction cannot be used to create contract")
+
+	// ErrInvalidBlobProof is returned if the KZG proofs of a blob transaction's
+	// sidecar do not verify against its blobs and commitments.
+	ErrInvalidBlobProof = errors.New("invalid blob proof")
+
+	// ErrMissingBlobSidecar is returned if a blob transaction is used in a place
+	// requiring its sidecar, but it has not been attached (or fetched) yet.
+	ErrMissingBlobSidecar = errors.New("missing sidecar in blob transaction")
+
+	// ErrNonceGapTooLarge is returned if a transaction's nonce is further ahead of
+	// the sender's account nonce than the pool allows.
+	ErrNonceGapTooLarge = errors.New("nonce gap too large")
+
+	// ErrBlobPoolFull is returned if a blob transaction was accepted, but the
+	// blob pool was at capacity and evicted it straight away as the cheapest one.
+	ErrBlobPoolFull = errors.New("blob pool full")
 )
+
+// validationErrors maps the transaction validation errors to the stable codes
+// reported over RPC, and to human readable explanations of the failed check
+// along with remediation advice, most specific first.
+var validationErrors = []struct {
+	err         error
+	code        string
+	name        string
+	explanation string
+}{
+	{core.ErrTxTypeNotSupported, "unsupported_type", "ErrTxTypeNotSupported", "The transaction type is not accepted by the pool, either because the fork introducing it is not active yet or the pool does not handle it. Resubmit the transaction using a type supported by the current fork."},
+	{ErrInvalidSender, "invalid_sender", "ErrInvalidSender", "The sender could not be recovered from the signature. Sign the transaction with the correct chain ID and make sure the signature values were not altered."},
+	{ErrOversizedData, "oversized_data", "ErrOversizedData", "The encoded transaction exceeds the maximum size accepted by the pool. Reduce the calldata or the number of blobs carried."},
+	{ErrTxBlobLimitExceeded, "blob_limit_exceeded", "ErrTxBlobLimitExceeded", "The transaction carries more blobs than the pool accepts in a single transaction. Split the blobs across multiple transactions."},
+	{ErrBlobTxNoContractCreation, "blob_contract_creation", "ErrBlobTxNoContractCreation", "Blob transactions cannot create contracts. Set an explicit recipient address."},
+	{ErrInvalidBlobProof, "invalid_blob_proof", "ErrInvalidBlobProof", "The KZG proof does not match the blob and commitment. Ensure the blob has not been mutated after proof computation, and that the proofs match the sidecar version of the active fork."},
+	{ErrMissingBlobSidecar, "missing_blob_sidecar", "ErrMissingBlobSidecar", "The blob transaction was submitted without its sidecar. Attach the blobs, commitments and proofs using the network encoding of the transaction."},
+	{ErrKZGTimeout, "kzg_timeout", "ErrKZGTimeout", "The KZG proof verification did not finish in time, which usually indicates an overloaded node rather than a faulty transaction. Retry the submission later."},
+	{ErrUnderpriced, "underpriced", "ErrUnderpriced", "The pool is full and the transaction pays less than the cheapest one already pooled. Raise the gas tip and fee caps."},
+	{ErrBlobPoolFull, "blob_pool_full", "ErrBlobPoolFull", "The blob pool is at capacity and the transaction was the cheapest one to evict. Raise the gas and blob fee caps, or retry once the pool drains."},
+	{ErrTxGasPriceTooLow, "gas_price_too_low", "ErrTxGasPriceTooLow", "The gas tip or blob fee cap is below the minimum accepted by the pool. Raise the offending price above the reported minimum."},
+	{ErrTxGasPriceTooHigh, "gas_price_too_high", "ErrTxGasPriceTooHigh", "The gas fee cap is above the maximum accepted by the pool, which usually indicates a unit mistake. Double check the fee cap is denominated in wei."},
+	{ErrGasLimit, "gas_limit", "ErrGasLimit", "The gas limit exceeds what the pool accepts relative to the block gas limit. Lower the gas limit to what the transaction actually needs."},
+	{ErrNegativeValue, "negative_value", "ErrNegativeValue", "The transferred value is negative. Set a non-negative value."},
+	{core.ErrTipAboveFeeCap, "tip_above_fee_cap", "ErrTipAboveFeeCap", "The gas tip cap is higher than the gas fee cap. Raise the fee cap to at least the tip cap, or lower the tip."},
+	{core.ErrFeeCapVeryHigh, "fee_cap_very_high", "ErrFeeCapVeryHigh", "The gas fee cap does not fit into 256 bits. Use a realistic fee cap."},
+	{core.ErrTipVeryHigh, "tip_very_high", "ErrTipVeryHigh", "The gas tip cap does not fit into 256 bits. Use a realistic tip cap."},
+	{core.ErrIntrinsicGas, "intrinsic_gas", "ErrIntrinsicGas", "The gas limit does not cover the intrinsic cost of the transaction (base cost, calldata and access list). Raise the gas limit to at least the reported minimum."},
+	{core.ErrFloorDataGas, "floor_data_gas", "ErrFloorDataGas", "The gas limit does not cover the calldata floor cost introduced by EIP-7623. Raise the gas limit to at least the reported minimum."},
+	{core.ErrGasLimitTooHigh, "gas_limit_too_high", "ErrGasLimitTooHigh", "The gas limit exceeds the per-transaction cap introduced by EIP-7825. Lower the gas limit below the cap."},
+	{core.ErrNonceMax, "nonce_max", "ErrNonceMax", "The nonce has reached its maximum value, the account cannot send further transactions."},
+	{core.ErrMaxInitCodeSizeExceeded, "max_init_code_size", "ErrMaxInitCodeSizeExceeded", "The contract creation code exceeds the EIP-3860 initcode size limit. Reduce the size of the deployed code or split the deployment."},
+	{core.ErrNonceTooLow, "nonce_too_low", "ErrNonceTooLow", "A transaction with the same nonce was already included. Use the next nonce of the account."},
+	{ErrNonceGapTooLarge, "nonce_gap_too_large", "ErrNonceGapTooLarge", "The nonce is too far ahead of the account's current nonce. Submit the transactions with the missing nonces first."},
+	{core.ErrNonceTooHigh, "nonce_too_high", "ErrNonceTooHigh", "The nonce leaves a gap after the account's pending transactions. Submit the transactions with the missing nonces first."},
+	{core.ErrInsufficientFunds, "insufficient_funds", "ErrInsufficientFunds", "The account balance does not cover the maximum cost of the transaction and the pending ones before it. Fund the account or lower the value and fee caps."},
+	{ErrAccountLimitExceeded, "account_limit_exceeded", "ErrAccountLimitExceeded", "The account already has as many pending transactions as the pool allows. Wait for some of them to be included."},
+}
+
+// FormatValidationError expands a transaction validation error into a human
+// readable explanation of the failed check, along with advice on how to fix the
+// transaction. Unknown errors are returned verbatim.
+func FormatValidationError(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, known := range validationErrors {
+		if errors.Is(err, known.err) {
+			return fmt.Sprintf("%s: %s Details: %v", known.name, known.explanation, err)
+		}
+	}
+	return err.Error()
+}
+
+// ValidationErrorCode returns the stable, machine readable code of a transaction
+// validation error, or an empty string if the error is not a known one.
+func ValidationErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, known := range validationErrors {
+		if errors.Is(err, known.err) {
+			return known.code
+		}
+	}
+	return ""
+}
@@ -440,6 +440,29 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that re-adding an already pooled transaction is rejected with the
+// standard duplicate sentinel error, both for pending and queued transactions.
+func TestAddAlreadyKnown(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	pending := transaction(0, 100000, key)
+	queued := transaction(2, 100000, key)
+	from, _ := deriveSender(pending)
+	testAddBalance(pool, from, big.NewInt(1000000))
+
+	for i, tx := range []*types.Transaction{pending, queued} {
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("tx %d: failed to add transaction: %v", i, err)
+		}
+		if err := pool.addRemoteSync(tx); !errors.Is(err, txpool.ErrAlreadyKnown) {
+			t.Errorf("tx %d: duplicate error mismatch: have %v, want %v", i, err, txpool.ErrAlreadyKnown)
+		}
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,59 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxPoolTx is a transaction tracked by the pool along with whether its blob
+// sidecar has been resolved. Blob transactions may be known to the pool before
+// their sidecar is fetched (e.g. from announcements), and this wrapper keeps that
+// state out of the transaction itself.
+type TxPoolTx struct {
+	Tx             *types.Transaction // Transaction, with its sidecar if fetched
+	SidecarFetched bool               // Whether the blob sidecar is attached, always true for non-blob transactions
+}
+
+// NewTxPoolTx wraps a transaction, marking the sidecar as fetched unless the
+// transaction references blobs without carrying them.
+func NewTxPoolTx(tx *types.Transaction) *TxPoolTx {
+	return &TxPoolTx{
+		Tx:             tx,
+		SidecarFetched: !tx.RequiresSidecar(),
+	}
+}
+
+// Transaction returns the wrapped transaction if it is complete, or an error if
+// it is a blob transaction whose sidecar has not been fetched yet.
+func (ptx *TxPoolTx) Transaction() (*types.Transaction, error) {
+	if !ptx.SidecarFetched {
+		return nil, ErrMissingBlobSidecar
+	}
+	return ptx.Tx, nil
+}
+
+// SetSidecar attaches a fetched sidecar to the wrapped blob transaction, after
+// checking that it matches the blob hashes committed to by the transaction.
+func (ptx *TxPoolTx) SetSidecar(sidecar *types.BlobTxSidecar) error {
+	if err := sidecar.ValidateBlobCommitmentHashes(ptx.Tx.BlobHashes()); err != nil {
+		return err
+	}
+	ptx.Tx = ptx.Tx.WithBlobTxSidecar(sidecar)
+	ptx.SidecarFetched = true
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the pool transaction wrapper tracks whether the sidecar of a blob
+// transaction has been fetched, and only hands out complete transactions.
+func TestTxPoolTx(t *testing.T) {
+	var (
+		blob          = new(kzg4844.Blob)
+		commitment, _ = kzg4844.BlobToCommitment(blob)
+		proof, _      = kzg4844.ComputeBlobProof(blob, commitment)
+		sidecar       = types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{*blob}, []kzg4844.Commitment{commitment}, []kzg4844.Proof{proof})
+		blobtx        = types.NewTx(&types.BlobTx{BlobFeeCap: uint256.NewInt(1), BlobHashes: sidecar.BlobHashes()})
+	)
+	// Non-blob and complete blob transactions are usable right away
+	for _, tx := range []*types.Transaction{types.NewTx(&types.LegacyTx{}), blobtx.WithBlobTxSidecar(sidecar)} {
+		ptx := NewTxPoolTx(tx)
+		if !ptx.SidecarFetched {
+			t.Errorf("type %d: sidecar not marked fetched", tx.Type())
+		}
+		if have, err := ptx.Transaction(); err != nil || have != tx {
+			t.Errorf("type %d: transaction mismatch: have %v, %v", tx.Type(), have, err)
+		}
+	}
+	// Blob transactions without sidecar are withheld until it is attached
+	ptx := NewTxPoolTx(blobtx)
+	if ptx.SidecarFetched {
+		t.Fatal("missing sidecar marked fetched")
+	}
+	if _, err := ptx.Transaction(); !errors.Is(err, ErrMissingBlobSidecar) {
+		t.Fatalf("incomplete transaction error mismatch: have %v, want %v", err, ErrMissingBlobSidecar)
+	}
+	mismatch := types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{*blob}, []kzg4844.Commitment{{0x01}}, []kzg4844.Proof{proof})
+	if err := ptx.SetSidecar(mismatch); err == nil {
+		t.Fatal("mismatching sidecar attached")
+	}
+	if ptx.SidecarFetched {
+		t.Fatal("mismatching sidecar marked fetched")
+	}
+	if err := ptx.SetSidecar(sidecar); err != nil {
+		t.Fatalf("failed to attach sidecar: %v", err)
+	}
+	tx, err := ptx.Transaction()
+	if err != nil {
+		t.Fatalf("failed to retrieve completed transaction: %v", err)
+	}
+	if tx.BlobTxSidecar() != sidecar || tx.Hash() != blobtx.Hash() {
+		t.Errorf("completed transaction mismatch: have sidecar %v, hash %x", tx.BlobTxSidecar(), tx.Hash())
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	blobProofCacheHitMeter  = metrics.NewRegisteredMeter("txpool/blobproofs/cache/hit", nil)
+	blobProofCacheMissMeter = metrics.NewRegisteredMeter("txpool/blobproofs/cache/miss", nil)
+)
+
+// verifyBlobProof and verifyCellProofs are the KZG proof verifiers, replaceable
+// in tests to track or stall the expensive verifications performed.
+var (
+	verifyBlobProof  = kzg4844.VerifyBlobProof
+	verifyCellProofs = kzg4844.VerifyCellProofs
+)
+
+// BlobProofCache is an LRU set of blob proofs which were already successfully
+// verified, allowing transactions re-added to the pool (e.g. after a reorg) to
+// skip the expensive KZG verification.
+//
+// Entries are keyed on the hash of the blob, commitment and proof together. The
+// transaction hash does not cover the sidecar and the commitment and proof pair
+// alone does not cover the blob, so neither would be safe to key on.
+type BlobProofCache struct {
+	cache *lru.Cache[common.Hash, struct{}]
+}
+
+// NewBlobProofCache creates a blob proof verification cache retaining up to the
+// given number of verified proofs.
+func NewBlobProofCache(size int) *BlobProofCache {
+	return &BlobProofCache{
+		cache: lru.NewCache[common.Hash, struct{}](size),
+	}
+}
+
+// verify checks the proof of a blob against its commitment, short circuiting if
+// the exact same triplet was already verified before. It is safe to call on a
+// nil cache, in which case the proof is always verified.
+func (c *BlobProofCache) verify(blob *kzg4844.Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
+	if c == nil {
+		return verifyBlobProof(blob, commitment, proof)
+	}
+	hasher := sha256.New()
+	hasher.Write(commitment[:])
+	hasher.Write(proof[:])
+	hasher.Write(blob[:])
+
+	var key common.Hash
+	hasher.Sum(key[:0])
+
+	if c.cache.Contains(key) {
+		blobProofCacheHitMeter.Mark(1)
+		return nil
+	}
+	blobProofCacheMissMeter.Mark(1)
+
+	if err := verifyBlobProof(blob, commitment, proof); err != nil {
+		return err
+	}
+	c.cache.Add(key, struct{}{})
+	return nil
+}
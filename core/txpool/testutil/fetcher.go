@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package testutil
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MockTxFetcherCallbacks implements the callbacks a transaction fetcher needs to
+// interact with the pool and the network, recording the calls made. Each method
+// delegates to the corresponding function field if set, or otherwise accepts
+// everything and does nothing. It is safe for concurrent use.
+//
+// The callbacks are passed to the fetcher as method values:
+//
+//	fetcher.NewTxFetcher(mock.ValidateMeta, mock.AddTxs, mock.FetchTxs, mock.DropPeer)
+type MockTxFetcherCallbacks struct {
+	ValidateMetaFunc func(hash common.Hash, kind byte) error
+	AddTxsFunc       func(txs []*types.Transaction) []error
+	FetchTxsFunc     func(peer string, hashes []common.Hash) error
+	DropPeerFunc     func(peer string)
+
+	validated int      // Number of transactions handed to AddTxs
+	fetched   int      // Number of transactions requested via FetchTxs
+	dropped   []string // Peers requested to be dropped, in order
+	lock      sync.Mutex
+}
+
+// ValidateMeta checks the metadata of an announced transaction.
+func (m *MockTxFetcherCallbacks) ValidateMeta(hash common.Hash, kind byte) error {
+	if m.ValidateMetaFunc != nil {
+		return m.ValidateMetaFunc(hash, kind)
+	}
+	return nil
+}
+
+// AddTxs imports a batch of delivered transactions into the pool.
+func (m *MockTxFetcherCallbacks) AddTxs(txs []*types.Transaction) []error {
+	m.lock.Lock()
+	m.validated += len(txs)
+	m.lock.Unlock()
+
+	if m.AddTxsFunc != nil {
+		return m.AddTxsFunc(txs)
+	}
+	return make([]error, len(txs))
+}
+
+// FetchTxs requests a batch of transactions from a peer.
+func (m *MockTxFetcherCallbacks) FetchTxs(peer string, hashes []common.Hash) error {
+	m.lock.Lock()
+	m.fetched += len(hashes)
+	m.lock.Unlock()
+
+	if m.FetchTxsFunc != nil {
+		return m.FetchTxsFunc(peer, hashes)
+	}
+	return nil
+}
+
+// DropPeer disconnects a misbehaving peer.
+func (m *MockTxFetcherCallbacks) DropPeer(peer string) {
+	m.lock.Lock()
+	m.dropped = append(m.dropped, peer)
+	m.lock.Unlock()
+
+	if m.DropPeerFunc != nil {
+		m.DropPeerFunc(peer)
+	}
+}
+
+// Validated returns the number of transactions handed to AddTxs so far.
+func (m *MockTxFetcherCallbacks) Validated() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.validated
+}
+
+// Fetched returns the number of transactions requested via FetchTxs so far.
+func (m *MockTxFetcherCallbacks) Fetched() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.fetched
+}
+
+// Dropped returns the peers requested to be dropped so far, in order.
+func (m *MockTxFetcherCallbacks) Dropped() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return slices.Clone(m.dropped)
+}
+
+// AssertDropPeerNotCalled fails the test if any peer was requested to be dropped.
+func (m *MockTxFetcherCallbacks) AssertDropPeerNotCalled(t testing.TB) {
+	t.Helper()
+	if dropped := m.Dropped(); len(dropped) > 0 {
+		t.Errorf("unexpected peer drops: %v", dropped)
+	}
+}
+
+// AssertValidationCount fails the test if the number of transactions handed to
+// AddTxs differs from the expected one.
+func (m *MockTxFetcherCallbacks) AssertValidationCount(t testing.TB, n int) {
+	t.Helper()
+	if have := m.Validated(); have != n {
+		t.Errorf("validated transaction count mismatch: have %d, want %d", have, n)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package testutil
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the fetcher callback mock defaults to accepting everything, defers
+// to the configured overrides and records the calls made.
+func TestMockTxFetcherCallbacks(t *testing.T) {
+	var (
+		mock MockTxFetcherCallbacks
+		txs  = []*types.Transaction{types.NewTx(&types.LegacyTx{Nonce: 0}), types.NewTx(&types.LegacyTx{Nonce: 1})}
+	)
+	// The defaults accept everything
+	if err := mock.ValidateMeta(common.Hash{}, types.LegacyTxType); err != nil {
+		t.Errorf("default metadata validation failed: %v", err)
+	}
+	if errs := mock.AddTxs(txs); len(errs) != len(txs) || errs[0] != nil || errs[1] != nil {
+		t.Errorf("default import results mismatch: %v", errs)
+	}
+	if err := mock.FetchTxs("A", []common.Hash{{0x01}}); err != nil {
+		t.Errorf("default fetch failed: %v", err)
+	}
+	mock.AssertDropPeerNotCalled(t)
+	mock.AssertValidationCount(t, len(txs))
+
+	// The overrides are invoked in place of the defaults
+	rejected := errors.New("rejected")
+	mock.ValidateMetaFunc = func(common.Hash, byte) error { return rejected }
+	mock.AddTxsFunc = func(txs []*types.Transaction) []error { return []error{rejected} }
+
+	var dropped string
+	mock.DropPeerFunc = func(peer string) { dropped = peer }
+
+	if err := mock.ValidateMeta(common.Hash{}, types.LegacyTxType); err != rejected {
+		t.Errorf("metadata validation override not invoked: %v", err)
+	}
+	if errs := mock.AddTxs(txs[:1]); len(errs) != 1 || errs[0] != rejected {
+		t.Errorf("import override not invoked: %v", errs)
+	}
+	mock.DropPeer("B")
+	if dropped != "B" {
+		t.Errorf("drop override not invoked")
+	}
+	mock.AssertValidationCount(t, len(txs)+1)
+	if have := mock.Fetched(); have != 1 {
+		t.Errorf("fetched transaction count mismatch: have %d, want 1", have)
+	}
+	if have := mock.Dropped(); !slices.Equal(have, []string{"B"}) {
+		t.Errorf("dropped peers mismatch: have %v, want [B]", have)
+	}
+}
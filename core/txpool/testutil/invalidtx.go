@@ -0,0 +1,152 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package testutil contains helpers for exercising the transaction pool
+// validation paths from tests and local reproduction harnesses.
+package testutil
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// NewInvalidTxMaker creates a generator of well-formed transactions of the given
+// type which are rejected by the stateless validation. Blob transactions carry a
+// valid signature but a blob proof which does not verify, all other types carry
+// an invalid signature.
+func NewInvalidTxMaker(txType uint8, chainConfig *params.ChainConfig) (func(nonce uint64) (*types.Transaction, error), error) {
+	switch txType {
+	case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType, types.SetCodeTxType:
+		return newInvalidSigTxMaker(txType, chainConfig), nil
+	case types.BlobTxType:
+		return newInvalidBlobTxMaker(chainConfig)
+	default:
+		return nil, fmt.Errorf("%w: %d", types.ErrTxTypeNotSupported, txType)
+	}
+}
+
+// newInvalidSigTxMaker creates a generator of transactions with an all-zero,
+// and thus unrecoverable, signature.
+func newInvalidSigTxMaker(txType uint8, chainConfig *params.ChainConfig) func(nonce uint64) (*types.Transaction, error) {
+	var (
+		chainID = uint256.MustFromBig(chainConfig.ChainID)
+		signer  = types.LatestSigner(chainConfig)
+		sig     = make([]byte, crypto.SignatureLength)
+	)
+	return func(nonce uint64) (*types.Transaction, error) {
+		var txData types.TxData
+		switch txType {
+		case types.LegacyTxType:
+			txData = &types.LegacyTx{
+				Nonce:    nonce,
+				GasPrice: common.Big2,
+				Gas:      params.TxGas,
+				To:       &common.Address{},
+			}
+		case types.AccessListTxType:
+			txData = &types.AccessListTx{
+				ChainID:  chainID.ToBig(),
+				Nonce:    nonce,
+				GasPrice: common.Big2,
+				Gas:      params.TxGas,
+				To:       &common.Address{},
+			}
+		case types.DynamicFeeTxType:
+			txData = &types.DynamicFeeTx{
+				ChainID:   chainID.ToBig(),
+				Nonce:     nonce,
+				GasTipCap: common.Big1,
+				GasFeeCap: common.Big2,
+				Gas:       params.TxGas,
+				To:        &common.Address{},
+			}
+		case types.SetCodeTxType:
+			txData = &types.SetCodeTx{
+				ChainID:   chainID,
+				Nonce:     nonce,
+				GasTipCap: uint256.NewInt(1),
+				GasFeeCap: uint256.NewInt(2),
+				Gas:       params.TxGas + params.CallNewAccountGas,
+				AuthList:  []types.SetCodeAuthorization{{ChainID: *chainID}},
+			}
+		}
+		return types.NewTx(txData).WithSignature(signer, sig)
+	}
+}
+
+// newInvalidBlobTxMaker creates a generator of signed blob transactions whose
+// sidecar passes the commitment hash checks, but fails KZG proof verification.
+func newInvalidBlobTxMaker(chainConfig *params.ChainConfig) (func(nonce uint64) (*types.Transaction, error), error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	chainID := uint256.MustFromBig(chainConfig.ChainID)
+
+	// Start from an all-zero blob (canonical), compute commitment+proof for it,
+	// then mutate the blob so the proof becomes invalid while remaining well-formed.
+	var blob kzg4844.Blob
+	commitment, err := kzg4844.BlobToCommitment(&blob)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := kzg4844.ComputeBlobProof(&blob, commitment)
+	if err != nil {
+		return nil, err
+	}
+	mutated := blob
+	mutated[31] = 1 // keeps the first field element canonical, but changes the blob
+
+	vhash := kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+	sidecar := types.NewBlobTxSidecar(
+		types.BlobSidecarVersion0,
+		[]kzg4844.Blob{mutated},
+		[]kzg4844.Commitment{commitment},
+		[]kzg4844.Proof{proof},
+	)
+	if err := sidecar.ValidateBlobCommitmentHashes([]common.Hash{vhash}); err != nil {
+		return nil, fmt.Errorf("unexpected commitment-hash validation failure: %w", err)
+	}
+	// Sanity: the proof must fail (otherwise we didn't construct the intended case).
+	if err := kzg4844.VerifyBlobProof(&sidecar.Blobs[0], sidecar.Commitments[0], sidecar.Proofs[0]); err == nil {
+		return nil, errors.New("constructed blob proof unexpectedly verifies")
+	}
+	signer, err := types.NewCancunSignerSafe(chainID.ToBig())
+	if err != nil {
+		return nil, err
+	}
+	return func(nonce uint64) (*types.Transaction, error) {
+		txData := &types.BlobTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.NewInt(2),
+			Gas:        params.TxGas,
+			BlobFeeCap: uint256.NewInt(params.BlobTxMinBlobGasprice + 1),
+			BlobHashes: []common.Hash{vhash},
+			Sidecar:    sidecar,
+		}
+		return types.SignNewTx(key, signer, txData)
+	}, nil
+}
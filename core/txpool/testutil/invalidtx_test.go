@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package testutil
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the generated transactions of every type are well-formed, but get
+// rejected by the stateless transaction validation.
+func TestInvalidTxMaker(t *testing.T) {
+	// Run on Prague rules, so the legacy blob sidecars are still accepted
+	config := *params.MergedTestChainConfig
+	config.OsakaTime = nil
+
+	var (
+		signer = types.LatestSigner(&config)
+		head   = &types.Header{
+			Number:     big.NewInt(1),
+			Time:       0,
+			GasLimit:   30_000_000,
+			Difficulty: big.NewInt(0),
+		}
+		opts = &txpool.ValidationOptions{
+			Config:       &config,
+			Accept:       1<<types.LegacyTxType | 1<<types.AccessListTxType | 1<<types.DynamicFeeTxType | 1<<types.BlobTxType | 1<<types.SetCodeTxType,
+			MaxSize:      1024 * 1024,
+			MaxBlobCount: 1,
+			MinTip:       big.NewInt(0),
+		}
+	)
+	tests := []struct {
+		txType uint8
+		sigErr error
+		valErr string
+	}{
+		{types.LegacyTxType, types.ErrInvalidSig, "invalid sender"},
+		{types.AccessListTxType, types.ErrInvalidSig, "invalid sender"},
+		{types.DynamicFeeTxType, types.ErrInvalidSig, "invalid sender"},
+		{types.SetCodeTxType, types.ErrInvalidSig, "invalid sender"},
+		{types.BlobTxType, nil, "opening proof"},
+	}
+	for _, tt := range tests {
+		makeTx, err := NewInvalidTxMaker(tt.txType, &config)
+		if err != nil {
+			t.Fatalf("type %d: failed to create maker: %v", tt.txType, err)
+		}
+		tx, err := makeTx(1)
+		if err != nil {
+			t.Fatalf("type %d: failed to make transaction: %v", tt.txType, err)
+		}
+		if tx.Type() != tt.txType {
+			t.Errorf("type %d: transaction type mismatch: have %d", tt.txType, tx.Type())
+		}
+		if tx.Nonce() != 1 {
+			t.Errorf("type %d: nonce mismatch: have %d, want 1", tt.txType, tx.Nonce())
+		}
+		if _, err := types.Sender(signer, tx); !errors.Is(err, tt.sigErr) {
+			t.Errorf("type %d: sender error mismatch: have %v, want %v", tt.txType, err, tt.sigErr)
+		}
+		if err := txpool.ValidateTransaction(tx, head, signer, opts); err == nil || !strings.Contains(err.Error(), tt.valErr) {
+			t.Errorf("type %d: validation error mismatch: have %v, want %q", tt.txType, err, tt.valErr)
+		}
+	}
+	if _, err := NewInvalidTxMaker(0x7f, &config); !errors.Is(err, types.ErrTxTypeNotSupported) {
+		t.Errorf("unknown type error mismatch: have %v, want %v", err, types.ErrTxTypeNotSupported)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// MockState is an in-memory account state implementing txpool.StateReader, with
+// configurable nonces and balances. Accounts not set have a zero nonce and
+// balance. It is safe for concurrent use.
+type MockState struct {
+	nonces   map[common.Address]uint64
+	balances map[common.Address]*uint256.Int
+	lock     sync.RWMutex
+}
+
+// NewMockState creates an empty mock account state.
+func NewMockState() *MockState {
+	return &MockState{
+		nonces:   make(map[common.Address]uint64),
+		balances: make(map[common.Address]*uint256.Int),
+	}
+}
+
+// GetNonce returns the nonce of an account.
+func (s *MockState) GetNonce(addr common.Address) uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.nonces[addr]
+}
+
+// SetNonce sets the nonce of an account.
+func (s *MockState) SetNonce(addr common.Address, nonce uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nonces[addr] = nonce
+}
+
+// GetBalance returns the balance of an account.
+func (s *MockState) GetBalance(addr common.Address) *uint256.Int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if balance, ok := s.balances[addr]; ok {
+		return new(uint256.Int).Set(balance)
+	}
+	return new(uint256.Int)
+}
+
+// SetBalance sets the balance of an account.
+func (s *MockState) SetBalance(addr common.Address, balance *uint256.Int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.balances[addr] = new(uint256.Int).Set(balance)
+}
+
+// MockSigner signs transactions on behalf of named identities (e.g. peer IDs),
+// each with a deterministic key derived from its name, so tests can refer to
+// senders by name without managing keys.
+type MockSigner struct {
+	signer types.Signer
+}
+
+// NewMockSigner creates a mock signer signing with the given signer scheme.
+func NewMockSigner(signer types.Signer) *MockSigner {
+	return &MockSigner{signer: signer}
+}
+
+// Key returns the private key belonging to an identity.
+func (s *MockSigner) Key(id string) *ecdsa.PrivateKey {
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte(id)))
+	if err != nil {
+		panic(err) // A keccak output outside the secp256k1 order is practically impossible
+	}
+	return key
+}
+
+// Address returns the account address belonging to an identity.
+func (s *MockSigner) Address(id string) common.Address {
+	return crypto.PubkeyToAddress(s.Key(id).PublicKey)
+}
+
+// Sign creates a transaction from the given data, signed by an identity.
+func (s *MockSigner) Sign(id string, txdata types.TxData) (*types.Transaction, error) {
+	return types.SignNewTx(s.Key(id), s.signer, txdata)
+}
+
+// MockClock is a simulated clock for driving time dependent pool logic from
+// tests. The zero value is ready to use and starts at time zero.
+type MockClock struct {
+	mclock.Simulated
+}
+
+// NewMockClock creates a simulated clock.
+func NewMockClock() *MockClock {
+	return new(MockClock)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package testutil
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+var _ txpool.StateReader = (*MockState)(nil)
+
+// Tests the account state, signer and clock mocks.
+func TestMocks(t *testing.T) {
+	// Accounts default to zero and retain their updates
+	state := NewMockState()
+	addr := common.Address{0x01}
+	if nonce, balance := state.GetNonce(addr), state.GetBalance(addr); nonce != 0 || !balance.IsZero() {
+		t.Fatalf("unset account mismatch: nonce %d, balance %v", nonce, balance)
+	}
+	state.SetNonce(addr, 3)
+	state.SetBalance(addr, uint256.NewInt(100))
+	state.GetBalance(addr).SetUint64(0) // Must not alias the stored balance
+	if nonce, balance := state.GetNonce(addr), state.GetBalance(addr); nonce != 3 || balance.Uint64() != 100 {
+		t.Fatalf("account mismatch: nonce %d, balance %v", nonce, balance)
+	}
+	// Identities sign deterministically with distinct keys
+	signer := NewMockSigner(types.LatestSignerForChainID(big.NewInt(1)))
+	if signer.Address("alice") != NewMockSigner(nil).Address("alice") {
+		t.Fatal("identity address not deterministic")
+	}
+	if signer.Address("alice") == signer.Address("bob") {
+		t.Fatal("identities share address")
+	}
+	tx, err := signer.Sign("alice", &types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), tx); err != nil || from != signer.Address("alice") {
+		t.Fatalf("sender mismatch: have %x, %v, want %x", from, err, signer.Address("alice"))
+	}
+	// The clock only moves when advanced
+	clock := NewMockClock()
+	start := clock.Now()
+	clock.Run(time.Minute)
+	if elapsed := clock.Now().Sub(start); elapsed != time.Minute {
+		t.Fatalf("clock elapsed mismatch: have %v, want %v", elapsed, time.Minute)
+	}
+}
@@ -19,7 +19,10 @@ package txpool
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -36,6 +39,43 @@ var (
 	blobTxMinBlobGasPrice = big.NewInt(params.BlobTxMinBlobGasprice)
 )
 
+// DefaultKZGTimeout is the maximum time allowed for verifying the KZG proofs of
+// a blob transaction if the validation options do not specify otherwise.
+const DefaultKZGTimeout = 5 * time.Second
+
+// KZGWorkers bounds the number of KZG proof verifications a pool runs in the
+// background. A verification abandoned after timing out only releases its slot
+// once it actually finishes, so a hanging KZG library cannot tie up more
+// goroutines than there are slots.
+type KZGWorkers struct {
+	slots chan struct{}
+}
+
+// NewKZGWorkers creates a limiter allowing n KZG verifications to run at the
+// same time, including the ones abandoned after timing out. It should match the
+// number of blob transactions the pool validates concurrently.
+func NewKZGWorkers(n int) *KZGWorkers {
+	if n < 1 {
+		n = 1
+	}
+	return &KZGWorkers{slots: make(chan struct{}, n)}
+}
+
+// acquire blocks until a verification slot is available. It is a noop on a nil
+// limiter.
+func (w *KZGWorkers) acquire() {
+	if w != nil {
+		w.slots <- struct{}{}
+	}
+}
+
+// release frees up a verification slot. It is a noop on a nil limiter.
+func (w *KZGWorkers) release() {
+	if w != nil {
+		<-w.slots
+	}
+}
+
 // ValidationOptions define certain differences between transaction validation
 // across the different pools without having to duplicate those checks.
 type ValidationOptions struct {
@@ -45,6 +85,48 @@ type ValidationOptions struct {
 	MaxSize      uint64   // Maximum size of a transaction that the caller can meaningfully handle
 	MaxBlobCount int      // Maximum number of blobs allowed per transaction
 	MinTip       *big.Int // Minimum gas tip needed to allow a transaction into the caller pool
+
+	// MaxEffectiveGasPrice, if set, is the maximum gas fee cap a transaction may
+	// offer, rejecting implausibly high (e.g. fat-fingered) fees. Nil disables
+	// the check.
+	MaxEffectiveGasPrice *big.Int
+
+	// GasCapMultiple scales the maximum gas a single transaction may use relative
+	// to the current block gas limit. A value of 1.0 forbids any transaction from
+	// using more gas than the head block permits. Zero is treated as 1.0, and a
+	// negative value disables the check.
+	GasCapMultiple float64
+
+	// ProofCache, if set, tracks the already verified blob proofs to avoid
+	// re-verifying them when a transaction is validated again.
+	ProofCache *BlobProofCache
+
+	// KZGTimeout is the maximum time to wait for the KZG proof verification of
+	// a blob transaction before rejecting it. Zero means DefaultKZGTimeout, and
+	// a negative value disables the timeout, running the verification inline.
+	//
+	// KZGWorkers, if set, bounds the number of verifications running in the
+	// background. The timeout only starts once a worker slot was acquired, so
+	// transactions waiting for a slot are not rejected. Nil means no bound.
+	KZGTimeout time.Duration
+	KZGWorkers *KZGWorkers
+
+	// MaxFutureNonce is the maximum number of nonces a transaction may be ahead of
+	// its sender's account nonce in NonceState. Zero or a nil NonceState disables
+	// the check.
+	MaxFutureNonce uint64
+	NonceState     StateReader
+
+	// ChainIDOverride, if set, validates transactions as if they were submitted
+	// to the chain with the given ID instead of Config.ChainID. It is meant for
+	// testing cross-chain replay protection.
+	ChainIDOverride *big.Int
+}
+
+// StateReader is the minimal state access needed to look up account nonces
+// during stateless validation.
+type StateReader interface {
+	GetNonce(addr common.Address) uint64
 }
 
 // ValidationFunction is an method type which the pools use to perform the tx-validations which do not
@@ -52,6 +134,52 @@ type ValidationOptions struct {
 // might choose to instead use something else, e.g. to always fail or avoid heavy cpu usage.
 type ValidationFunction func(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error
 
+// Validate implements TxTypeValidator, allowing plain functions to be registered
+// as type specific validators.
+func (f ValidationFunction) Validate(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error {
+	return f(tx, head, signer, opts)
+}
+
+// TxTypeValidator performs the validations specific to a single transaction type,
+// run by ValidateTransaction after all the type agnostic checks passed.
+type TxTypeValidator interface {
+	Validate(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error
+}
+
+var (
+	txTypeValidators     = make(map[byte]TxTypeValidator)
+	txTypeValidatorsLock sync.RWMutex
+)
+
+func init() {
+	RegisterTxTypeValidator(types.BlobTxType, ValidationFunction(func(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error {
+		return validateBlobTx(tx, head, opts)
+	}))
+	RegisterTxTypeValidator(types.SetCodeTxType, ValidationFunction(validateSetCodeTx))
+}
+
+// RegisterTxTypeValidator sets the validator of the type specific rules of a
+// transaction type. It is meant to be called from init functions and panics if
+// the type already has a validator registered.
+func RegisterTxTypeValidator(txType byte, v TxTypeValidator) {
+	txTypeValidatorsLock.Lock()
+	defer txTypeValidatorsLock.Unlock()
+
+	if _, ok := txTypeValidators[txType]; ok {
+		panic(fmt.Sprintf("txpool: duplicate validator for tx type %d", txType))
+	}
+	txTypeValidators[txType] = v
+}
+
+// txTypeValidator returns the validator registered for a transaction type, or
+// nil if the type has no specific rules.
+func txTypeValidator(txType byte) TxTypeValidator {
+	txTypeValidatorsLock.RLock()
+	defer txTypeValidatorsLock.RUnlock()
+
+	return txTypeValidators[txType]
+}
+
 // ValidateTransaction is a helper method to check whether a transaction is valid
 // according to the consensus rules, but does not check state-dependent validation
 // (balance, nonce, etc).
@@ -73,6 +201,11 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	}
 	// Ensure only transactions that have been enabled are accepted
 	rules := opts.Config.Rules(head.Number, head.Difficulty.Sign() == 0, head.Time)
+	if opts.ChainIDOverride != nil {
+		config := *opts.Config
+		config.ChainID = opts.ChainIDOverride
+		signer = types.MakeSigner(&config, head.Number, head.Time)
+	}
 	if !rules.IsBerlin && tx.Type() != types.LegacyTxType {
 		return fmt.Errorf("%w: type %d rejected, pool not yet in Berlin", core.ErrTxTypeNotSupported, tx.Type())
 	}
@@ -97,9 +230,14 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if tx.Value().Sign() < 0 {
 		return ErrNegativeValue
 	}
-	// Ensure the transaction doesn't exceed the current block limit gas
-	if head.GasLimit < tx.Gas() {
-		return ErrGasLimit
+	// Ensure the transaction doesn't exceed the (scaled) current block limit gas
+	if multiple := opts.GasCapMultiple; multiple >= 0 {
+		if multiple == 0 {
+			multiple = 1
+		}
+		if limit := scaleGasLimit(head.GasLimit, multiple); limit < tx.Gas() {
+			return fmt.Errorf("%w: gas %v, limit %v", ErrGasLimit, tx.Gas(), limit)
+		}
 	}
 	// Sanity check for extremely large numbers (supported by RLP or RPC)
 	if tx.GasFeeCap().BitLen() > 256 {
@@ -112,14 +250,28 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
 		return core.ErrTipAboveFeeCap
 	}
+	// Typed transactions carry a y-parity instead of the legacy (EIP-155) V
+	// value, reject anything else with a clearer error than the sender recovery
+	if tx.Type() != types.LegacyTxType {
+		if v, _, _ := tx.RawSignatureValues(); v.BitLen() > 1 {
+			return fmt.Errorf("%w: %w: typed transaction with legacy v value %v, want y-parity 0 or 1", ErrInvalidSender, types.ErrInvalidSig, v)
+		}
+	}
 	// Make sure the transaction is signed properly
-	if _, err := types.Sender(signer, tx); err != nil {
+	from, err := types.Sender(signer, tx)
+	if err != nil {
 		return fmt.Errorf("%w: %v", ErrInvalidSender, err)
 	}
 	// Limit nonce to 2^64-1 per EIP-2681
 	if tx.Nonce()+1 < tx.Nonce() {
 		return core.ErrNonceMax
 	}
+	// Reject nonces too far in the future if the caller requested so
+	if opts.MaxFutureNonce > 0 && opts.NonceState != nil {
+		if next := opts.NonceState.GetNonce(from); tx.Nonce() > next && tx.Nonce()-next > opts.MaxFutureNonce {
+			return fmt.Errorf("%w: next nonce %v, tx nonce %v, limit %v", ErrNonceGapTooLarge, next, tx.Nonce(), opts.MaxFutureNonce)
+		}
+	}
 	// Ensure the transaction has more gas than the bare minimum needed to cover
 	// the transaction metadata
 	intrGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, true, rules.IsIstanbul, rules.IsShanghai)
@@ -143,23 +295,46 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
 		return fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.GasTipCap(), opts.MinTip)
 	}
-	if tx.Type() == types.BlobTxType {
-		return validateBlobTx(tx, head, opts)
+	if opts.MaxEffectiveGasPrice != nil && tx.GasFeeCapIntCmp(opts.MaxEffectiveGasPrice) > 0 {
+		return fmt.Errorf("%w: gas fee cap %v, maximum allowed %v", ErrTxGasPriceTooHigh, tx.GasFeeCap(), opts.MaxEffectiveGasPrice)
 	}
-	if tx.Type() == types.SetCodeTxType {
-		if len(tx.SetCodeAuthorizations()) == 0 {
-			return errors.New("set code tx must have at least one authorization tuple")
-		}
+	// Run the checks specific to the transaction type, if any
+	if v := txTypeValidator(tx.Type()); v != nil {
+		return v.Validate(tx, head, signer, opts)
 	}
 	return nil
 }
 
+// validateSetCodeTx implements the set-code-transaction specific validations.
+func validateSetCodeTx(tx *types.Transaction, head *types.Header, signer types.Signer, opts *ValidationOptions) error {
+	if len(tx.SetCodeAuthorizations()) == 0 {
+		return errors.New("set code tx must have at least one authorization tuple")
+	}
+	return nil
+}
+
+// scaleGasLimit multiplies the given gas limit by a floating point factor,
+// saturating at the maximum representable gas amount.
+func scaleGasLimit(limit uint64, multiple float64) uint64 {
+	scaled := float64(limit) * multiple
+	if scaled >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(scaled)
+}
+
 // validateBlobTx implements the blob-transaction specific validations.
 func validateBlobTx(tx *types.Transaction, head *types.Header, opts *ValidationOptions) error {
-	sidecar := tx.BlobTxSidecar()
-	if sidecar == nil {
-		return errors.New("missing sidecar in blob transaction")
+	if tx.To() == nil {
+		return ErrBlobTxNoContractCreation
+	}
+	if !tx.HasBlobs() {
+		return errors.New("blobless blob transaction")
+	}
+	if tx.RequiresSidecar() {
+		return ErrMissingBlobSidecar
 	}
+	sidecar := tx.BlobTxSidecar()
 	// Ensure the sidecar is constructed with the correct version, consistent
 	// with the current fork.
 	version := types.BlobSidecarVersion0
@@ -176,33 +351,76 @@ func validateBlobTx(tx *types.Transaction, head *types.Header, opts *ValidationO
 	// Ensure the number of items in the blob transaction and various side
 	// data match up before doing any expensive validations
 	hashes := tx.BlobHashes()
-	if len(hashes) == 0 {
-		return errors.New("blobless blob transaction")
-	}
 	if len(hashes) > params.BlobTxMaxBlobs {
 		return fmt.Errorf("too many blobs in transaction: have %d, permitted %d", len(hashes), params.BlobTxMaxBlobs)
 	}
-	if len(sidecar.Blobs) != len(hashes) {
-		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sidecar.Blobs), len(hashes))
+	if sidecar.NumBlobs() != len(hashes) {
+		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", sidecar.NumBlobs(), len(hashes))
+	}
+	for i, hash := range hashes {
+		if err := kzg4844.ValidateBlobHashVersion(hash); err != nil {
+			return fmt.Errorf("blob %d: %v", i, err)
+		}
 	}
 	if err := sidecar.ValidateBlobCommitmentHashes(hashes); err != nil {
 		return err
 	}
-	// Fork-specific sidecar checks, including proof verification.
-	if sidecar.Version == types.BlobSidecarVersion1 {
-		return validateBlobSidecarOsaka(sidecar, hashes)
-	} else {
-		return validateBlobSidecarLegacy(sidecar, hashes)
+	// Fork-specific sidecar checks, including proof verification. Unless hang
+	// protection is disabled, these are run in the background so a misbehaving
+	// KZG library cannot stall the pool; if it does hang, the verification is
+	// abandoned and the transaction rejected, but it keeps holding its worker
+	// slot until it finishes. The deadline only starts once a slot is held, so
+	// queueing for one doesn't count against the verification.
+	verify := func() error {
+		if sidecar.Version == types.BlobSidecarVersion1 {
+			return validateBlobSidecarOsaka(sidecar, hashes)
+		}
+		return validateBlobSidecarLegacy(sidecar, hashes, opts.ProofCache)
+	}
+	timeout := opts.KZGTimeout
+	if timeout < 0 {
+		return verify()
+	}
+	if timeout == 0 {
+		timeout = DefaultKZGTimeout
+	}
+	opts.KZGWorkers.acquire()
+
+	errc := make(chan error, 1)
+	go func() {
+		defer opts.KZGWorkers.release()
+		errc <- verify()
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("%w: %v", ErrKZGTimeout, timeout)
 	}
 }
 
-func validateBlobSidecarLegacy(sidecar *types.BlobTxSidecar, hashes []common.Hash) error {
+func validateBlobSidecarLegacy(sidecar *types.BlobTxSidecar, hashes []common.Hash, cache *BlobProofCache) error {
 	if len(sidecar.Proofs) != len(hashes) {
 		return fmt.Errorf("invalid number of %d blob proofs expected %d", len(sidecar.Proofs), len(hashes))
 	}
-	for i := range sidecar.Blobs {
-		if err := kzg4844.VerifyBlobProof(&sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]); err != nil {
-			return fmt.Errorf("invalid blob %d: %v", i, err)
+	for i := range hashes {
+		blob, err := sidecar.BlobAt(i)
+		if err != nil {
+			return err
+		}
+		commitment, err := sidecar.CommitmentAt(i)
+		if err != nil {
+			return err
+		}
+		proof, err := sidecar.ProofAt(i)
+		if err != nil {
+			return err
+		}
+		if err := cache.verify(blob, commitment, proof); err != nil {
+			return fmt.Errorf("%w: blob %d: %v", ErrInvalidBlobProof, i, err)
 		}
 	}
 	return nil
@@ -212,7 +430,10 @@ func validateBlobSidecarOsaka(sidecar *types.BlobTxSidecar, hashes []common.Hash
 	if len(sidecar.Proofs) != len(hashes)*kzg4844.CellProofsPerBlob {
 		return fmt.Errorf("invalid number of %d blob proofs expected %d", len(sidecar.Proofs), len(hashes)*kzg4844.CellProofsPerBlob)
 	}
-	return kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs)
+	if err := verifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBlobProof, err)
+	}
+	return nil
 }
 
 // ValidationOptionsWithState define certain differences between stateful transaction
@@ -238,6 +459,12 @@ type ValidationOptionsWithState struct {
 	// ExistingCost is a mandatory callback to retrieve an already pooled
 	// transaction's cost with the given nonce to check for overdrafts.
 	ExistingCost func(addr common.Address, nonce uint64) *big.Int
+
+	// BaseFee and BlobBaseFee are the optional fees of the pending block, used
+	// to compute the total cost of a transaction for the balance checks. If not
+	// set, the cost is computed with the transaction's fee caps.
+	BaseFee     *big.Int
+	BlobBaseFee *big.Int
 }
 
 // ValidateTransactionWithState is a helper method to check whether a transaction
@@ -266,7 +493,7 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	// Ensure the transactor has enough funds to cover the transaction costs
 	var (
 		balance = opts.State.GetBalance(from).ToBig()
-		cost    = tx.Cost()
+		cost    = tx.TotalCost(opts.BaseFee, opts.BlobBaseFee)
 	)
 	if balance.Cmp(cost) < 0 {
 		return fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
@@ -19,15 +19,24 @@ package txpool
 import (
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool/testutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 func TestValidateTransactionEIP2681(t *testing.T) {
@@ -47,11 +56,12 @@ func TestValidateTransactionEIP2681(t *testing.T) {
 
 	// Create validation options
 	opts := &ValidationOptions{
-		Config:       params.TestChainConfig,
-		Accept:       0xFF, // Accept all transaction types
-		MaxSize:      32 * 1024,
-		MaxBlobCount: 6,
-		MinTip:       big.NewInt(0),
+		Config:         params.TestChainConfig,
+		Accept:         0xFF, // Accept all transaction types
+		MaxSize:        32 * 1024,
+		MaxBlobCount:   6,
+		MinTip:         big.NewInt(0),
+		GasCapMultiple: 1,
 	}
 
 	tests := []struct {
@@ -113,3 +123,638 @@ func createTestTransaction(key *ecdsa.PrivateKey, nonce uint64) *types.Transacti
 	signedTx, _ := types.SignTx(tx, types.HomesteadSigner{}, key)
 	return signedTx
 }
+
+// Tests that the transaction gas limit is checked against the scaled block gas
+// limit, that a zero multiple defaults to the block gas limit itself, and that a
+// negative multiple disables the check.
+func TestValidateTransactionGasCapMultiple(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   20000,
+		Time:       1,
+		Difficulty: big.NewInt(1),
+	}
+	signer := types.LatestSigner(params.TestChainConfig)
+	tx := createTestTransaction(key, 0) // 21000 gas
+
+	tests := []struct {
+		multiple float64
+		wantErr  error
+	}{
+		{multiple: 0, wantErr: ErrGasLimit},
+		{multiple: -1, wantErr: nil},
+		{multiple: 1, wantErr: ErrGasLimit},
+		{multiple: 1.05, wantErr: nil},
+		{multiple: 1.04, wantErr: ErrGasLimit},
+		{multiple: math.Inf(1), wantErr: nil},
+	}
+	for _, tt := range tests {
+		opts := &ValidationOptions{
+			Config:         params.TestChainConfig,
+			Accept:         0xFF,
+			MaxSize:        32 * 1024,
+			MinTip:         big.NewInt(0),
+			GasCapMultiple: tt.multiple,
+		}
+		if err := ValidateTransaction(tx, head, signer, opts); !errors.Is(err, tt.wantErr) {
+			t.Errorf("multiple %v: error mismatch: have %v, want %v", tt.multiple, err, tt.wantErr)
+		}
+	}
+}
+
+// Tests that typed transactions carrying a legacy style V value instead of a
+// y-parity are rejected as having an invalid signature.
+func TestValidateTransactionTypedLegacyV(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   5000000,
+		Time:       1,
+		Difficulty: big.NewInt(1),
+	}
+	signer := types.LatestSigner(params.TestChainConfig)
+	opts := &ValidationOptions{
+		Config:  params.TestChainConfig,
+		Accept:  0xFF,
+		MaxSize: 32 * 1024,
+		MinTip:  big.NewInt(0),
+	}
+	unsigned := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Gas:       21000,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		To:        &common.Address{0x01},
+	})
+	sig, err := crypto.Sign(signer.Hash(unsigned).Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		v       byte
+		wantErr error
+	}{
+		{"y-parity", 0, nil},
+		{"homestead v", 27, types.ErrInvalidSig},
+		{"eip-155 v", byte(params.TestChainConfig.ChainID.Uint64()*2 + 35), types.ErrInvalidSig},
+	}
+	for _, tt := range tests {
+		mangled := slices.Clone(sig)
+		mangled[crypto.RecoveryIDOffset] += tt.v
+
+		tx, err := unsigned.WithSignature(signer, mangled)
+		if err != nil {
+			t.Fatalf("%s: failed to sign transaction: %v", tt.name, err)
+		}
+		err = ValidateTransaction(tx, head, signer, opts)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if tt.wantErr != nil && !errors.Is(err, ErrInvalidSender) {
+			t.Errorf("%s: error not flagged as invalid sender: %v", tt.name, err)
+		}
+	}
+	// Legacy transactions are allowed to carry any (valid) V value
+	if err := ValidateTransaction(createTestTransaction(key, 0), head, signer, opts); err != nil {
+		t.Errorf("legacy transaction rejected: %v", err)
+	}
+}
+
+// Tests that transactions offering a gas fee cap above the configured maximum
+// are rejected, while the maximum itself is still accepted.
+func TestValidateTransactionMaxEffectiveGasPrice(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   5000000,
+		Time:       1,
+		Difficulty: big.NewInt(1),
+	}
+	signer := types.LatestSigner(params.TestChainConfig)
+	opts := &ValidationOptions{
+		Config:  params.TestChainConfig,
+		Accept:  0xFF,
+		MaxSize: 32 * 1024,
+		MinTip:  big.NewInt(0),
+	}
+	maxPrice := big.NewInt(params.GWei)
+	tests := []struct {
+		name     string
+		maxPrice *big.Int
+		feeCap   *big.Int
+		wantErr  error
+	}{
+		{"disabled", nil, new(big.Int).Add(maxPrice, common.Big1), nil},
+		{"at maximum", maxPrice, maxPrice, nil},
+		{"above maximum", maxPrice, new(big.Int).Add(maxPrice, common.Big1), ErrTxGasPriceTooHigh},
+	}
+	for _, tt := range tests {
+		opts.MaxEffectiveGasPrice = tt.maxPrice
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Gas:       21000,
+			GasFeeCap: tt.feeCap,
+			GasTipCap: big.NewInt(1),
+			To:        &common.Address{0x01},
+		})
+		if err := ValidateTransaction(tx, head, signer, opts); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// Tests that re-validating a blob transaction with a proof cache does not verify
+// the KZG proofs a second time, but a mutated blob is still caught.
+func TestValidateTransactionBlobProofCache(t *testing.T) {
+	var verifications int
+	defer func(verify func(*kzg4844.Blob, kzg4844.Commitment, kzg4844.Proof) error) {
+		verifyBlobProof = verify
+	}(verifyBlobProof)
+	verifyBlobProof = func(blob *kzg4844.Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
+		verifications++
+		return kzg4844.VerifyBlobProof(blob, commitment, proof)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   5000000,
+		Time:       1,
+		Difficulty: common.Big0,
+	}
+	config := params.CancunTestChainConfig
+	signer := types.LatestSigner(config)
+	opts := &ValidationOptions{
+		Config:         config,
+		Accept:         1 << types.BlobTxType,
+		MaxSize:        1024 * 1024,
+		MaxBlobCount:   1,
+		MinTip:         big.NewInt(0),
+		GasCapMultiple: 1,
+		ProofCache:     NewBlobProofCache(16),
+	}
+	var (
+		blob          = new(kzg4844.Blob)
+		commitment, _ = kzg4844.BlobToCommitment(blob)
+		proof, _      = kzg4844.ComputeBlobProof(blob, commitment)
+		sidecar       = types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{*blob}, []kzg4844.Commitment{commitment}, []kzg4844.Proof{proof})
+	)
+	tx := types.MustSignNewTx(key, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(config.ChainID),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(1),
+		Gas:        21000,
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	for i := 0; i < 2; i++ {
+		if err := ValidateTransaction(tx, head, signer, opts); err != nil {
+			t.Fatalf("validation %d failed: %v", i, err)
+		}
+	}
+	if verifications != 1 {
+		t.Errorf("proof verification count mismatch: have %d, want 1", verifications)
+	}
+	// Mutate the blob while retaining the commitment and proof, the cache must
+	// not treat it as already verified.
+	mutated := sidecar.Copy()
+	mutated.Blobs[0][0] = 0x01
+	if err := ValidateTransaction(tx.WithBlobTxSidecar(mutated), head, signer, opts); err == nil {
+		t.Error("mutated blob accepted")
+	}
+	if verifications != 2 {
+		t.Errorf("proof verification count mismatch: have %d, want 2", verifications)
+	}
+}
+
+// Tests that a hanging KZG proof verification does not block the validation, but
+// rejects the transaction once the configured timeout elapses, and that abandoned
+// verifications keep counting against the worker limit until they finish without
+// timing out the validations waiting for a slot.
+func TestValidateTransactionKZGTimeout(t *testing.T) {
+	var (
+		entered = make(chan struct{}, 1)
+		release = make(chan struct{})
+	)
+	defer func(verify func(*kzg4844.Blob, kzg4844.Commitment, kzg4844.Proof) error) {
+		verifyBlobProof = verify
+	}(verifyBlobProof)
+	verifyBlobProof = func(blob *kzg4844.Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
+		select {
+		case entered <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   5000000,
+		Time:       1,
+		Difficulty: common.Big0,
+	}
+	config := params.CancunTestChainConfig
+	signer := types.LatestSigner(config)
+	opts := &ValidationOptions{
+		Config:         config,
+		Accept:         1 << types.BlobTxType,
+		MaxSize:        1024 * 1024,
+		MaxBlobCount:   1,
+		MinTip:         big.NewInt(0),
+		GasCapMultiple: 1,
+		KZGTimeout:     50 * time.Millisecond,
+		KZGWorkers:     NewKZGWorkers(1),
+	}
+	var (
+		blob          = new(kzg4844.Blob)
+		commitment, _ = kzg4844.BlobToCommitment(blob)
+		proof, _      = kzg4844.ComputeBlobProof(blob, commitment)
+		sidecar       = types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{*blob}, []kzg4844.Commitment{commitment}, []kzg4844.Proof{proof})
+	)
+	tx := types.MustSignNewTx(key, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(config.ChainID),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(1),
+		Gas:        21000,
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	start := time.Now()
+	if err := ValidateTransaction(tx, head, signer, opts); !errors.Is(err, ErrKZGTimeout) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrKZGTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("validation took too long: %v", elapsed)
+	}
+	<-entered
+
+	// The abandoned verification still holds the only worker slot, so further
+	// validations must wait for it without starting another verification, and
+	// without their timeout running meanwhile
+	errc := make(chan error, 1)
+	go func() {
+		errc <- ValidateTransaction(tx, head, signer, opts)
+	}()
+	select {
+	case <-entered:
+		t.Fatal("verification started over the worker limit")
+	case err := <-errc:
+		t.Fatalf("validation finished without a worker slot: %v", err)
+	case <-time.After(4 * opts.KZGTimeout):
+	}
+	// Unblock the stalled verification and ensure its slot is reused
+	close(release)
+
+	if err := <-errc; err != nil {
+		t.Fatalf("failed to validate transaction: %v", err)
+	}
+	// Ensure verification works inline with the timeout disabled
+	opts.KZGTimeout = -1
+	if err := ValidateTransaction(tx, head, signer, opts); err != nil {
+		t.Fatalf("failed to validate transaction inline: %v", err)
+	}
+}
+
+// Tests that blob transactions sending to the zero address are accepted, whereas
+// contract creations cannot even be represented, being rejected by the decoder
+// before ever reaching validation.
+func TestValidateTransactionBlobTxContractCreation(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   5000000,
+		Time:       1,
+		Difficulty: common.Big0,
+	}
+	config := params.CancunTestChainConfig
+	signer := types.LatestSigner(config)
+	opts := &ValidationOptions{
+		Config:         config,
+		Accept:         1 << types.BlobTxType,
+		MaxSize:        1024 * 1024,
+		MaxBlobCount:   1,
+		MinTip:         big.NewInt(0),
+		GasCapMultiple: 1,
+	}
+	var (
+		blob          = new(kzg4844.Blob)
+		commitment, _ = kzg4844.BlobToCommitment(blob)
+		proof, _      = kzg4844.ComputeBlobProof(blob, commitment)
+		sidecar       = types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{*blob}, []kzg4844.Commitment{commitment}, []kzg4844.Proof{proof})
+	)
+	tx := types.MustSignNewTx(key, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(config.ChainID),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(1),
+		Gas:        21000,
+		To:         common.Address{},
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	if to := tx.To(); to == nil || *to != (common.Address{}) {
+		t.Fatalf("recipient mismatch: have %v, want zero address", to)
+	}
+	if err := ValidateTransaction(tx, head, signer, opts); err != nil {
+		t.Errorf("zero address recipient rejected: %v", err)
+	}
+	// Assemble a blob transaction without a recipient by hand, the decoder must
+	// refuse it as the recipient is mandatory.
+	create, err := rlp.EncodeToBytes(struct {
+		ChainID    *uint256.Int
+		Nonce      uint64
+		GasTipCap  *uint256.Int
+		GasFeeCap  *uint256.Int
+		Gas        uint64
+		To         *common.Address `rlp:"nil"`
+		Value      *uint256.Int
+		Data       []byte
+		AccessList types.AccessList
+		BlobFeeCap *uint256.Int
+		BlobHashes []common.Hash
+		V, R, S    *uint256.Int
+	}{
+		ChainID:    uint256.MustFromBig(config.ChainID),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(1),
+		Gas:        21000,
+		Value:      new(uint256.Int),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: sidecar.BlobHashes(),
+		V:          new(uint256.Int),
+		R:          new(uint256.Int),
+		S:          new(uint256.Int),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode contract creation: %v", err)
+	}
+	if err := new(types.Transaction).UnmarshalBinary(append([]byte{types.BlobTxType}, create...)); err == nil {
+		t.Error("blob transaction without recipient decoded")
+	}
+}
+
+// Tests that validation errors are expanded into explanations based on their
+// wrapped sentinel, leaving unknown errors untouched.
+func TestFormatValidationError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{errors.New("unknown failure"), "unknown failure"},
+		{
+			fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, 100, 21000),
+			"ErrIntrinsicGas: The gas limit does not cover the intrinsic cost of the transaction (base cost, calldata and access list). Raise the gas limit to at least the reported minimum. Details: intrinsic gas too low: gas 100, minimum needed 21000",
+		},
+		{
+			fmt.Errorf("%w: blob %d: %v", ErrInvalidBlobProof, 0, "can't verify opening proof"),
+			"ErrInvalidBlobProof: The KZG proof does not match the blob and commitment. Ensure the blob has not been mutated after proof computation, and that the proofs match the sidecar version of the active fork. Details: invalid blob proof: blob 0: can't verify opening proof",
+		},
+		{
+			fmt.Errorf("%w: %w: bad v", ErrInvalidSender, types.ErrInvalidSig),
+			"ErrInvalidSender: The sender could not be recovered from the signature. Sign the transaction with the correct chain ID and make sure the signature values were not altered. Details: invalid sender: invalid transaction v, r, s values: bad v",
+		},
+	}
+	for i, tt := range tests {
+		if have := FormatValidationError(tt.err); have != tt.want {
+			t.Errorf("test %d: explanation mismatch:\nhave %q\nwant %q", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that validation errors map to their stable codes based on their wrapped
+// sentinel, and that every known error has a unique code.
+func TestValidationErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{errors.New("unknown failure"), ""},
+		{fmt.Errorf("%w: blob 0: bad proof", ErrInvalidBlobProof), "invalid_blob_proof"},
+		{fmt.Errorf("%w: nonce 10, limit 5", ErrNonceGapTooLarge), "nonce_gap_too_large"},
+		{ErrBlobPoolFull, "blob_pool_full"},
+		{ErrMissingBlobSidecar, "missing_blob_sidecar"},
+	}
+	for i, tt := range tests {
+		if have := ValidationErrorCode(tt.err); have != tt.want {
+			t.Errorf("test %d: code mismatch: have %q, want %q", i, have, tt.want)
+		}
+	}
+	codes := make(map[string]struct{})
+	for _, known := range validationErrors {
+		if _, ok := codes[known.code]; ok || known.code == "" {
+			t.Errorf("%s: invalid or duplicate code %q", known.name, known.code)
+		}
+		codes[known.code] = struct{}{}
+	}
+}
+
+// Tests that ValidateTransaction defers to the validator registered for the type
+// of the transaction, and that types can only be registered once.
+func TestValidateTransactionTypeValidator(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		head   = &types.Header{Number: big.NewInt(1), GasLimit: 5000000, Time: 1, Difficulty: big.NewInt(1)}
+		signer = types.LatestSigner(params.TestChainConfig)
+		opts   = &ValidationOptions{
+			Config:       params.TestChainConfig,
+			Accept:       0xFF,
+			MaxSize:      32 * 1024,
+			MaxBlobCount: 6,
+			MinTip:       big.NewInt(0),
+		}
+		to = common.Address{0x01}
+		tx = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			To:        &to,
+			Gas:       21000,
+			GasFeeCap: big.NewInt(1),
+			GasTipCap: big.NewInt(1),
+		})
+	)
+	if err := ValidateTransaction(tx, head, signer, opts); err != nil {
+		t.Fatalf("failed to validate transaction: %v", err)
+	}
+	// Register a validator for the type, rejecting everything
+	errRejected := errors.New("rejected")
+	RegisterTxTypeValidator(types.DynamicFeeTxType, ValidationFunction(func(*types.Transaction, *types.Header, types.Signer, *ValidationOptions) error {
+		return errRejected
+	}))
+	defer func() {
+		txTypeValidatorsLock.Lock()
+		delete(txTypeValidators, types.DynamicFeeTxType)
+		txTypeValidatorsLock.Unlock()
+	}()
+	if err := ValidateTransaction(tx, head, signer, opts); !errors.Is(err, errRejected) {
+		t.Fatalf("registered validator not run: have %v, want %v", err, errRejected)
+	}
+	// Ensure duplicate registrations are refused
+	defer func() {
+		if recover() == nil {
+			t.Errorf("duplicate validator registration accepted")
+		}
+	}()
+	RegisterTxTypeValidator(types.BlobTxType, ValidationFunction(validateSetCodeTx))
+}
+
+// Tests that transactions too far ahead of their sender's nonce are rejected if
+// a future nonce limit is configured, and accepted otherwise.
+func TestValidateTransactionMaxFutureNonce(t *testing.T) {
+	var (
+		head   = &types.Header{Number: big.NewInt(1), GasLimit: 5000000, Time: 1, Difficulty: big.NewInt(1)}
+		signer = types.HomesteadSigner{}
+		sender = testutil.NewMockSigner(signer)
+		state  = testutil.NewMockState()
+	)
+	state.SetNonce(sender.Address("alice"), 10)
+	tests := []struct {
+		nonce   uint64
+		limit   uint64
+		state   StateReader
+		wantErr error
+	}{
+		{nonce: 5, limit: 16, state: state},
+		{nonce: 26, limit: 16, state: state},
+		{nonce: 27, limit: 16, state: state, wantErr: ErrNonceGapTooLarge},
+		{nonce: 27, limit: 0, state: state},
+		{nonce: 27, limit: 16, state: nil},
+	}
+	for i, tt := range tests {
+		opts := &ValidationOptions{
+			Config:         params.TestChainConfig,
+			Accept:         0xFF,
+			MaxSize:        32 * 1024,
+			MinTip:         big.NewInt(0),
+			MaxFutureNonce: tt.limit,
+			NonceState:     tt.state,
+		}
+		err := ValidateTransaction(createTestTransaction(sender.Key("alice"), tt.nonce), head, signer, opts)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.wantErr)
+		}
+	}
+}
+
+// Tests that a chain ID override makes transactions signed for the configured
+// chain fail sender recovery, guarding against cross-chain replays.
+func TestValidateTransactionChainIDOverride(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		head   = &types.Header{Number: big.NewInt(1), GasLimit: 5000000, Time: 1, Difficulty: big.NewInt(1)}
+		signer = types.LatestSignerForChainID(big.NewInt(1))
+		tx     = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(1),
+			GasTipCap: big.NewInt(1),
+			To:        &common.Address{0x01},
+		})
+	)
+	tests := []struct {
+		override *big.Int
+		wantErr  error
+	}{
+		{override: nil},
+		{override: big.NewInt(1)},
+		{override: big.NewInt(2), wantErr: ErrInvalidSender},
+	}
+	for i, tt := range tests {
+		opts := &ValidationOptions{
+			Config:          params.TestChainConfig,
+			Accept:          0xFF,
+			MaxSize:         32 * 1024,
+			MinTip:          big.NewInt(0),
+			ChainIDOverride: tt.override,
+		}
+		if err := ValidateTransaction(tx, head, signer, opts); !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.wantErr)
+		}
+	}
+}
+
+// Tests that the balance checks charge blob transactions their total cost at the
+// pending fees if known, falling back to the fee caps otherwise.
+func TestValidateTransactionWithStateTotalCost(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		config = params.CancunTestChainConfig
+		signer = types.LatestSigner(config)
+		tx     = types.MustSignNewTx(key, signer, &types.BlobTx{
+			ChainID:    uint256.MustFromBig(config.ChainID),
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.NewInt(10),
+			Gas:        21000,
+			BlobFeeCap: uint256.NewInt(2),
+			BlobHashes: []common.Hash{{0x01}},
+		})
+		cost = tx.Cost().Uint64() // 21000*10 + 131072*2 = 472144
+	)
+	tests := []struct {
+		balance     uint64
+		spent       uint64
+		baseFee     *big.Int
+		blobBaseFee *big.Int
+		wantErr     error
+	}{
+		// Without pending fees, the fee caps are charged
+		{balance: cost},
+		{balance: cost - 1, wantErr: core.ErrInsufficientFunds},
+
+		// Pending fees below the caps lower the cost: 21000*6 + 131072*1 = 257072
+		{balance: 257072, baseFee: big.NewInt(5), blobBaseFee: big.NewInt(1)},
+		{balance: 257071, baseFee: big.NewInt(5), blobBaseFee: big.NewInt(1), wantErr: core.ErrInsufficientFunds},
+
+		// A blob base fee above the cap is covered by Cost but not by TotalCost:
+		// 21000*6 + 131072*10 = 1436720
+		{balance: cost, baseFee: big.NewInt(5), blobBaseFee: big.NewInt(10), wantErr: core.ErrInsufficientFunds},
+		{balance: 1436720, baseFee: big.NewInt(5), blobBaseFee: big.NewInt(10)},
+
+		// The queued expenditure is checked against the total cost too
+		{balance: 257072 + 1000, spent: 1000, baseFee: big.NewInt(5), blobBaseFee: big.NewInt(1)},
+		{balance: 257072 + 999, spent: 1000, baseFee: big.NewInt(5), blobBaseFee: big.NewInt(1), wantErr: core.ErrInsufficientFunds},
+	}
+	for i, tt := range tests {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(tt.balance), tracing.BalanceChangeUnspecified)
+
+		opts := &ValidationOptionsWithState{
+			State:               statedb,
+			ExistingExpenditure: func(common.Address) *big.Int { return new(big.Int).SetUint64(tt.spent) },
+			ExistingCost:        func(common.Address, uint64) *big.Int { return nil },
+			BaseFee:             tt.baseFee,
+			BlobBaseFee:         tt.blobBaseFee,
+		}
+		if err := ValidateTransactionWithState(tx, signer, opts); !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.wantErr)
+		}
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
+	"slices"
 	"sync/atomic"
 	"time"
 
@@ -37,6 +39,7 @@ var (
 	ErrTxTypeNotSupported   = errors.New("transaction type not supported")
 	ErrGasFeeCapTooLow      = errors.New("fee cap less than base fee")
 	ErrUint256Overflow      = errors.New("bigint overflow, too large for uint256")
+	ErrInvalidBlobTx        = errors.New("invalid blob transaction")
 	errShortTypedTx         = errors.New("typed transaction too short")
 	errInvalidYParity       = errors.New("'yParity' field must be 0 or 1")
 	errVYParityMismatch     = errors.New("'v' and 'yParity' fields do not match")
@@ -52,6 +55,25 @@ const (
 	SetCodeTxType    = 0x04
 )
 
+// TransactionTypeName returns the human readable name of a transaction type for
+// logging, or Unknown(N) for unsupported types.
+func TransactionTypeName(txType byte) string {
+	switch txType {
+	case LegacyTxType:
+		return "Legacy"
+	case AccessListTxType:
+		return "AccessList"
+	case DynamicFeeTxType:
+		return "DynamicFee"
+	case BlobTxType:
+		return "Blob"
+	case SetCodeTxType:
+		return "SetCode"
+	default:
+		return fmt.Sprintf("Unknown(%d)", txType)
+	}
+}
+
 // Transaction is an Ethereum transaction.
 type Transaction struct {
 	inner TxData    // Consensus contents of a transaction
@@ -219,13 +241,14 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 	return inner, err
 }
 
-// setDecoded sets the inner transaction and size after decoding.
+// setDecoded sets the inner transaction and size after decoding. The caches are
+// reset, as the transaction may be reused to decode a different one.
 func (tx *Transaction) setDecoded(inner TxData, size uint64) {
 	tx.inner = inner
 	tx.time = time.Now()
-	if size > 0 {
-		tx.size.Store(size)
-	}
+	tx.hash.Store(nil)
+	tx.from.Store(nil)
+	tx.size.Store(size)
 }
 
 func sanityCheckSignature(v *big.Int, r *big.Int, s *big.Int, maybeProtected bool) error {
@@ -325,6 +348,26 @@ func (tx *Transaction) Cost() *big.Int {
 	return total
 }
 
+// TotalCost returns the amount a blob transaction pays if included at the given
+// base fee and blob base fee, i.e. value + (gas * effectiveGasPrice) + (blobGas *
+// blobBaseFee). A nil base fee or blob base fee falls back to the respective fee
+// cap. For all other transaction types it is equal to Cost.
+func (tx *Transaction) TotalCost(baseFee, blobBaseFee *big.Int) *big.Int {
+	blobtx, ok := tx.inner.(*BlobTx)
+	if !ok {
+		return tx.Cost()
+	}
+	total := blobtx.effectiveGasPrice(new(big.Int), baseFee)
+	total.Mul(total, new(big.Int).SetUint64(blobtx.Gas))
+
+	blobFee := blobBaseFee
+	if blobFee == nil {
+		blobFee = blobtx.BlobFeeCap.ToBig()
+	}
+	total.Add(total, new(big.Int).Mul(blobFee, new(big.Int).SetUint64(blobtx.blobGas())))
+	return total.Add(total, blobtx.Value.ToBig())
+}
+
 // RawSignatureValues returns the V, R, S signature values of the transaction.
 // The return values should not be modified by the caller.
 // The return values may be nil or zero, if the transaction is unsigned.
@@ -425,6 +468,46 @@ func (tx *Transaction) BlobGas() uint64 {
 	return 0
 }
 
+// GasUsedUpperBound returns the maximum combined execution and blob gas that the
+// transaction may consume, saturating at the uint64 limit. For non-blob
+// transactions it equals the gas limit.
+func (tx *Transaction) GasUsedUpperBound() uint64 {
+	gas := tx.Gas()
+	total := gas + tx.BlobGas()
+	if total < gas {
+		return math.MaxUint64
+	}
+	return total
+}
+
+// Weight returns the priority weight of the transaction for the given base fee
+// and blob base fee, in total wei: the effective gas tip over the gas limit, plus
+// for blob transactions the blob fee over the blob gas, so the blob fee is
+// accounted for in the ordering. A nil blob base fee falls back to the blob fee
+// cap.
+//
+// Transactions which cannot pay the given base fee or blob base fee weigh zero.
+func (tx *Transaction) Weight(baseFee, blobBaseFee *big.Int) *big.Int {
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return new(big.Int)
+	}
+	weight := tip.Mul(tip, new(big.Int).SetUint64(tx.Gas()))
+
+	blobtx, ok := tx.inner.(*BlobTx)
+	if !ok {
+		return weight
+	}
+	blobFee := blobtx.BlobFeeCap.ToBig()
+	if blobBaseFee != nil {
+		if blobFee.Cmp(blobBaseFee) < 0 {
+			return new(big.Int)
+		}
+		blobFee = blobBaseFee
+	}
+	return weight.Add(weight, new(big.Int).Mul(blobFee, new(big.Int).SetUint64(blobtx.blobGas())))
+}
+
 // BlobGasFeeCap returns the blob gas fee cap per blob gas of the transaction for blob transactions, nil otherwise.
 func (tx *Transaction) BlobGasFeeCap() *big.Int {
 	if blobtx, ok := tx.inner.(*BlobTx); ok {
@@ -441,6 +524,18 @@ func (tx *Transaction) BlobHashes() []common.Hash {
 	return nil
 }
 
+// HasBlobs returns whether the transaction is a blob transaction referencing at
+// least one blob.
+func (tx *Transaction) HasBlobs() bool {
+	return len(tx.BlobHashes()) > 0
+}
+
+// RequiresSidecar returns whether the transaction references blobs, but has no
+// sidecar attached to it (e.g. it was stripped for the consensus encoding).
+func (tx *Transaction) RequiresSidecar() bool {
+	return tx.HasBlobs() && tx.BlobTxSidecar() == nil
+}
+
 // BlobTxSidecar returns the sidecar of a blob transaction, nil otherwise.
 func (tx *Transaction) BlobTxSidecar() *BlobTxSidecar {
 	if blobtx, ok := tx.inner.(*BlobTx); ok {
@@ -483,6 +578,23 @@ func (tx *Transaction) WithoutBlobTxSidecar() *Transaction {
 	return cpy
 }
 
+// Clone returns a deep copy of the transaction, including its blob sidecar, if
+// any. The size cache is not carried over, as the sidecar of the copy may be
+// modified independently, changing the encoded size.
+func (tx *Transaction) Clone() *Transaction {
+	cpy := &Transaction{
+		inner: tx.inner.copy(),
+		time:  tx.time,
+	}
+	if h := tx.hash.Load(); h != nil {
+		cpy.hash.Store(h)
+	}
+	if f := tx.from.Load(); f != nil {
+		cpy.from.Store(f)
+	}
+	return cpy
+}
+
 // WithBlobTxSidecar returns a copy of tx with the blob sidecar added.
 func (tx *Transaction) WithBlobTxSidecar(sideCar *BlobTxSidecar) *Transaction {
 	blobtx, ok := tx.inner.(*BlobTx)
@@ -625,6 +737,38 @@ func (s Transactions) EncodeIndex(i int, w *bytes.Buffer) {
 	}
 }
 
+// TransactionEquals reports whether two transactions are the same from a
+// consensus perspective, i.e. whether their hashes match. The blob sidecar and
+// any locally cached metadata (size, sender, first seen time) are ignored, so a
+// blob transaction is equal to its stripped variant.
+func TransactionEquals(a, b *Transaction) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Hash() == b.Hash()
+}
+
+// TransactionDeepEquals reports whether two transactions are consensus-equal
+// and, in case of blob transactions, also carry identical sidecars. It is meant
+// to be used by tests wishing to verify that the network encoding of a tx has
+// been preserved, as opposed to TransactionEquals which only checks the hash.
+func TransactionDeepEquals(a, b *Transaction) bool {
+	if !TransactionEquals(a, b) {
+		return false
+	}
+	if a == nil {
+		return true
+	}
+	sa, sb := a.BlobTxSidecar(), b.BlobTxSidecar()
+	if sa == nil || sb == nil {
+		return sa == sb
+	}
+	return sa.Version == sb.Version &&
+		slices.Equal(sa.Blobs, sb.Blobs) &&
+		slices.Equal(sa.Commitments, sb.Commitments) &&
+		slices.Equal(sa.Proofs, sb.Proofs)
+}
+
 // TxDifference returns a new set of transactions that are present in a but not in b.
 func TxDifference(a, b Transactions) Transactions {
 	keep := make(Transactions, 0, len(a))
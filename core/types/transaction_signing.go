@@ -21,6 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -38,6 +41,8 @@ type sigCache struct {
 }
 
 // MakeSigner returns a Signer based on the given chain config and block number.
+// The signer matches the fork rules active at the given block, so it is suitable
+// for replaying historical blocks as well.
 func MakeSigner(config *params.ChainConfig, blockNumber *big.Int, blockTime uint64) Signer {
 	var signer Signer
 	switch {
@@ -89,6 +94,39 @@ func LatestSigner(config *params.ChainConfig) Signer {
 	return signer
 }
 
+// SignerFromChainID returns the most up-to-date Signer for the given chain ID at
+// the given block time. Time based forks are resolved against the schedule of
+// the config, whereas block number based forks are considered active as soon as
+// they are scheduled, as in LatestSigner.
+//
+// Use this when the chain ID is known separately from the config, e.g. when
+// handling transactions for a network whose config is shared with others. If
+// config is nil, the signer is the same as returned by LatestSignerForChainIDSafe.
+// An error is returned if the chain ID is not positive.
+func SignerFromChainID(chainID *big.Int, blockTime uint64, config *params.ChainConfig) (Signer, error) {
+	if chainID == nil {
+		return HomesteadSigner{}, nil
+	}
+	if config == nil {
+		return LatestSignerForChainIDSafe(chainID)
+	}
+	london := config.LondonBlock
+	switch {
+	case config.IsPrague(london, blockTime):
+		return NewPragueSignerSafe(chainID)
+	case config.IsCancun(london, blockTime):
+		return NewCancunSignerSafe(chainID)
+	case config.LondonBlock != nil:
+		return NewLondonSignerSafe(chainID)
+	case config.BerlinBlock != nil:
+		return newModernSignerSafe(chainID, forks.Berlin)
+	case config.EIP155Block != nil:
+		return NewEIP155Signer(chainID), nil
+	default:
+		return HomesteadSigner{}, nil
+	}
+}
+
 // LatestSignerForChainID returns the 'most permissive' Signer available. Specifically,
 // this enables support for EIP-155 replay protection and all implemented EIP-2718
 // transaction types if chainID is non-nil.
@@ -116,8 +154,38 @@ func SignTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, err
 	return tx.WithSignature(s, sig)
 }
 
-// SignNewTx creates a transaction and signs it.
+// BatchSignTx signs all the transactions with the given key, spreading the work
+// across up to GOMAXPROCS goroutines. The signed transactions and errors are
+// returned in the order of the input; failed entries are left nil.
+func BatchSignTx(txs []*Transaction, s Signer, prv *ecdsa.PrivateKey) ([]*Transaction, []error) {
+	var (
+		signed  = make([]*Transaction, len(txs))
+		errs    = make([]error, len(txs))
+		next    atomic.Int64
+		wg      sync.WaitGroup
+		workers = min(runtime.GOMAXPROCS(0), len(txs))
+	)
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(txs); i = int(next.Add(1) - 1) {
+				signed[i], errs[i] = SignTx(txs[i], s, prv)
+			}
+		}()
+	}
+	wg.Wait()
+	return signed, errs
+}
+
+// SignNewTx creates a transaction and signs it. Blob transactions are validated
+// before signing, see BlobTx.Validate.
 func SignNewTx(prv *ecdsa.PrivateKey, s Signer, txdata TxData) (*Transaction, error) {
+	if blobtx, ok := txdata.(*BlobTx); ok {
+		if err := blobtx.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	return SignTx(NewTx(txdata), s, prv)
 }
 
@@ -156,6 +224,28 @@ func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 	return addr, nil
 }
 
+// SenderHint stores addr in the transaction's sender cache as the address derived
+// with the given signer, so subsequent Sender calls with an equal signer return
+// it without recovering the public key. It returns the transaction itself.
+//
+// The address is not verified, so it must only be used with senders derived
+// earlier from this very transaction, e.g. by a prior validation.
+func (tx *Transaction) SenderHint(signer Signer, addr common.Address) *Transaction {
+	tx.from.Store(&sigCache{signer: signer, from: addr})
+	return tx
+}
+
+// SignatureOf returns the V, R, S signature values of the transaction, after
+// ensuring the signer is appropriate for the transaction type and chain, and
+// that a valid sender can be derived from the signature.
+func SignatureOf(signer Signer, tx *Transaction) (v, r, s *big.Int, err error) {
+	if _, err := Sender(signer, tx); err != nil {
+		return nil, nil, nil, err
+	}
+	v, r, s = tx.RawSignatureValues()
+	return new(big.Int).Set(v), new(big.Int).Set(r), new(big.Int).Set(s), nil
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -308,6 +398,43 @@ func NewCancunSigner(chainId *big.Int) Signer {
 	return newModernSigner(chainId, forks.Cancun)
 }
 
+// NewPragueSignerSafe is like NewPragueSigner, but returns an error instead of
+// panicking if the chain ID is nil or not positive.
+func NewPragueSignerSafe(chainId *big.Int) (Signer, error) {
+	return newModernSignerSafe(chainId, forks.Prague)
+}
+
+// NewCancunSignerSafe is like NewCancunSigner, but returns an error instead of
+// panicking if the chain ID is nil or not positive.
+func NewCancunSignerSafe(chainId *big.Int) (Signer, error) {
+	return newModernSignerSafe(chainId, forks.Cancun)
+}
+
+// NewLondonSignerSafe is like NewLondonSigner, but returns an error instead of
+// panicking if the chain ID is nil or not positive.
+func NewLondonSignerSafe(chainId *big.Int) (Signer, error) {
+	return newModernSignerSafe(chainId, forks.London)
+}
+
+// LatestSignerForChainIDSafe is like LatestSignerForChainID, but returns an error
+// instead of panicking if the chain ID is not positive. A nil chain ID results
+// in a HomesteadSigner, same as with LatestSignerForChainID.
+func LatestSignerForChainIDSafe(chainID *big.Int) (Signer, error) {
+	if chainID == nil {
+		return HomesteadSigner{}, nil
+	}
+	return NewPragueSignerSafe(chainID)
+}
+
+// newModernSignerSafe validates the chain ID before creating the signer, so
+// that callers handling untrusted configs can avoid the constructor panic.
+func newModernSignerSafe(chainId *big.Int, fork forks.Fork) (Signer, error) {
+	if chainId == nil || chainId.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChainId, chainId)
+	}
+	return newModernSigner(chainId, fork), nil
+}
+
 // NewLondonSigner returns a signer that accepts
 // - EIP-1559 dynamic fee transactions
 // - EIP-2930 access list transactions,
@@ -140,6 +140,83 @@ func TestChainId(t *testing.T) {
 	}
 }
 
+// Tests that the signature values of a transaction are only returned if the
+// signer is appropriate for it.
+func TestSignatureOf(t *testing.T) {
+	key, _ := defaultTestKey()
+
+	tx, err := SignNewTx(key, NewLondonSigner(big.NewInt(1)), &DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		signer Signer
+		err    error
+	}{
+		{NewLondonSigner(big.NewInt(1)), nil},
+		{NewCancunSigner(big.NewInt(1)), nil},
+		{NewLondonSigner(big.NewInt(2)), ErrInvalidChainId},
+		{NewEIP155Signer(big.NewInt(1)), ErrTxTypeNotSupported},
+		{HomesteadSigner{}, ErrTxTypeNotSupported},
+	}
+	for i, tt := range tests {
+		v, r, s, err := SignatureOf(tt.signer, tx)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		wantV, wantR, wantS := tx.RawSignatureValues()
+		if v.Cmp(wantV) != 0 || r.Cmp(wantR) != 0 || s.Cmp(wantS) != 0 {
+			t.Errorf("test %d: signature mismatch: have (%v, %v, %v), want (%v, %v, %v)", i, v, r, s, wantV, wantR, wantS)
+		}
+	}
+}
+
+// Tests that a sender hint is served from the cache without recovering the
+// sender or allocating, and only to signers equal to the hinted one.
+func TestSenderHint(t *testing.T) {
+	key, addr := defaultTestKey()
+	signer := NewLondonSigner(big.NewInt(1))
+
+	signed, err := SignNewTx(key, signer, &DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Decode a fresh copy of the transaction to start with an empty cache
+	blob, _ := signed.MarshalBinary()
+	tx := new(Transaction)
+	if err := tx.UnmarshalBinary(blob); err != nil {
+		t.Fatal(err)
+	}
+	// Hint a bogus sender, which Sender should return verbatim if no recovery
+	// is done
+	hint := common.Address{0xde, 0xad}
+	if tx.SenderHint(signer, hint) != tx {
+		t.Fatal("hinted transaction mismatch")
+	}
+	if from, err := Sender(signer, tx); err != nil || from != hint {
+		t.Fatalf("hinted sender mismatch: have %v (%v), want %v", from, err, hint)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { Sender(signer, tx) }); allocs != 0 {
+		t.Errorf("hinted sender allocations: have %v, want 0", allocs)
+	}
+	// A different signer should ignore the hint and recover the real sender
+	if from, err := Sender(NewCancunSigner(big.NewInt(1)), tx); err != nil || from != addr {
+		t.Errorf("recovered sender mismatch: have %v (%v), want %v", from, err, addr)
+	}
+}
+
 type nilSigner struct {
 	v, r, s *big.Int
 	Signer
@@ -149,6 +226,166 @@ func (ns *nilSigner) SignatureValues(tx *Transaction, sig []byte) (r, s, v *big.
 	return ns.v, ns.r, ns.s, nil
 }
 
+// TestSafeSignerConstructors ensures the safe signer constructors reject invalid
+// chain IDs with an error instead of panicking.
+func TestSafeSignerConstructors(t *testing.T) {
+	constructors := map[string]func(*big.Int) (Signer, error){
+		"prague": NewPragueSignerSafe,
+		"cancun": NewCancunSignerSafe,
+		"london": NewLondonSignerSafe,
+	}
+	for name, newSigner := range constructors {
+		for _, chainID := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+			if _, err := newSigner(chainID); !errors.Is(err, ErrInvalidChainId) {
+				t.Errorf("%s: chain ID %v: have error %v, want %v", name, chainID, err, ErrInvalidChainId)
+			}
+		}
+		signer, err := newSigner(big.NewInt(1))
+		if err != nil {
+			t.Fatalf("%s: failed to create signer: %v", name, err)
+		}
+		if signer.ChainID().Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("%s: chain ID mismatch: have %v, want 1", name, signer.ChainID())
+		}
+	}
+	// The latest signer falls back to homestead without a chain ID
+	if signer, err := LatestSignerForChainIDSafe(nil); err != nil || !signer.Equal(HomesteadSigner{}) {
+		t.Errorf("nil chain ID: have signer %T, error %v, want homestead signer", signer, err)
+	}
+	if _, err := LatestSignerForChainIDSafe(big.NewInt(0)); !errors.Is(err, ErrInvalidChainId) {
+		t.Errorf("zero chain ID: have error %v, want %v", err, ErrInvalidChainId)
+	}
+}
+
+// Tests that batch signing produces the same transactions as signing them one by
+// one, keeping the input order and reporting per-transaction failures.
+func TestBatchSignTx(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	signer := NewLondonSigner(big.NewInt(1))
+
+	txs := make([]*Transaction, 100)
+	for i := range txs {
+		txs[i] = NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: uint64(i), GasTipCap: common.Big1, GasFeeCap: common.Big1, Gas: 21000, To: &addr})
+	}
+	txs[42] = NewTx(&DynamicFeeTx{ChainID: big.NewInt(2), Nonce: 42})
+
+	signed, errs := BatchSignTx(txs, signer, key)
+	if len(signed) != len(txs) || len(errs) != len(txs) {
+		t.Fatalf("result length mismatch: have %d txs and %d errors, want %d", len(signed), len(errs), len(txs))
+	}
+	for i, tx := range signed {
+		if i == 42 {
+			if !errors.Is(errs[i], ErrInvalidChainId) || tx != nil {
+				t.Errorf("tx %d: have %v (err %v), want ErrInvalidChainId", i, tx, errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("tx %d: failed to sign: %v", i, errs[i])
+		}
+		want, _ := SignTx(txs[i], signer, key)
+		if tx.Hash() != want.Hash() {
+			t.Errorf("tx %d: hash mismatch: have %x, want %x", i, tx.Hash(), want.Hash())
+		}
+		if from, err := Sender(signer, tx); err != nil || from != addr {
+			t.Errorf("tx %d: sender mismatch: have %x (err %v), want %x", i, from, err, addr)
+		}
+	}
+	if signed, errs := BatchSignTx(nil, signer, key); len(signed) != 0 || len(errs) != 0 {
+		t.Errorf("empty batch: have %d txs and %d errors", len(signed), len(errs))
+	}
+}
+
+// TestMakeSigner ensures the signer selected for a block matches the fork rules
+// active at its number and time.
+func TestMakeSigner(t *testing.T) {
+	var (
+		chainID = big.NewInt(1)
+		cancun  = uint64(100)
+		prague  = uint64(200)
+	)
+	config := &params.ChainConfig{
+		ChainID:        chainID,
+		HomesteadBlock: big.NewInt(1),
+		EIP155Block:    big.NewInt(2),
+		BerlinBlock:    big.NewInt(3),
+		LondonBlock:    big.NewInt(4),
+		CancunTime:     &cancun,
+		PragueTime:     &prague,
+	}
+	tests := []struct {
+		number uint64
+		time   uint64
+		want   Signer
+	}{
+		{0, 0, FrontierSigner{}},
+		{1, 0, HomesteadSigner{}},
+		{2, 0, NewEIP155Signer(chainID)},
+		{3, 0, NewEIP2930Signer(chainID)},
+		{4, 0, NewLondonSigner(chainID)},
+		{4, 99, NewLondonSigner(chainID)},
+		{5, 100, NewCancunSigner(chainID)},
+		{6, 200, NewPragueSigner(chainID)},
+	}
+	for _, tt := range tests {
+		have := MakeSigner(config, new(big.Int).SetUint64(tt.number), tt.time)
+		if !have.Equal(tt.want) {
+			t.Errorf("block %d, time %d: signer mismatch: have %T, want %T", tt.number, tt.time, have, tt.want)
+		}
+	}
+}
+
+// TestSignerFromChainID ensures the signer is created for the requested chain ID,
+// following the time based forks of the config.
+func TestSignerFromChainID(t *testing.T) {
+	var (
+		chainID = big.NewInt(5)
+		cancun  = uint64(100)
+		prague  = uint64(200)
+	)
+	config := &params.ChainConfig{
+		ChainID:     big.NewInt(1),
+		EIP155Block: big.NewInt(0),
+		BerlinBlock: big.NewInt(0),
+		LondonBlock: big.NewInt(0),
+		CancunTime:  &cancun,
+		PragueTime:  &prague,
+	}
+	tests := []struct {
+		chainID *big.Int
+		time    uint64
+		config  *params.ChainConfig
+		want    Signer
+	}{
+		{nil, 0, config, HomesteadSigner{}},
+		{chainID, 0, nil, NewPragueSigner(chainID)},
+		{chainID, 99, config, NewLondonSigner(chainID)},
+		{chainID, 100, config, NewCancunSigner(chainID)},
+		{chainID, 200, config, NewPragueSigner(chainID)},
+		{chainID, 0, &params.ChainConfig{EIP155Block: big.NewInt(10), BerlinBlock: big.NewInt(20)}, NewEIP2930Signer(chainID)},
+		{chainID, 0, &params.ChainConfig{EIP155Block: big.NewInt(10)}, NewEIP155Signer(chainID)},
+		{chainID, 0, &params.ChainConfig{}, HomesteadSigner{}},
+	}
+	for i, tt := range tests {
+		have, err := SignerFromChainID(tt.chainID, tt.time, tt.config)
+		if err != nil {
+			t.Fatalf("test %d: failed to create signer: %v", i, err)
+		}
+		if !have.Equal(tt.want) {
+			t.Errorf("test %d: signer mismatch: have %T (chain %v), want %T (chain %v)", i, have, have.ChainID(), tt.want, tt.want.ChainID())
+		}
+	}
+	// Invalid chain IDs should be rejected for all the modern signers
+	for _, cfg := range []*params.ChainConfig{nil, config, {BerlinBlock: big.NewInt(0)}} {
+		for _, time := range []uint64{0, 100, 200} {
+			if _, err := SignerFromChainID(big.NewInt(0), time, cfg); !errors.Is(err, ErrInvalidChainId) {
+				t.Errorf("time %d: have error %v, want %v", time, err, ErrInvalidChainId)
+			}
+		}
+	}
+}
+
 // TestNilSigner ensures a faulty Signer implementation does not result in nil signature values or panics.
 func TestNilSigner(t *testing.T) {
 	key, _ := crypto.GenerateKey()
@@ -200,3 +437,27 @@ func Benchmark_modernSigner_Equal(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkSignTx(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	signer := NewLondonSigner(big.NewInt(1))
+
+	txs := make([]*Transaction, 1024)
+	for i := range txs {
+		txs[i] = NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: uint64(i), Gas: 21000})
+	}
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			for _, tx := range txs {
+				if _, err := SignTx(tx, signer, key); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for b.Loop() {
+			BatchSignTx(txs, signer, key)
+		}
+	})
+}
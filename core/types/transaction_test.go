@@ -25,6 +25,7 @@ import (
 	"maps"
 	"math/big"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -74,6 +75,26 @@ var (
 	)
 )
 
+func TestTransactionTypeName(t *testing.T) {
+	tests := []struct {
+		txType byte
+		want   string
+	}{
+		{LegacyTxType, "Legacy"},
+		{AccessListTxType, "AccessList"},
+		{DynamicFeeTxType, "DynamicFee"},
+		{BlobTxType, "Blob"},
+		{SetCodeTxType, "SetCode"},
+		{0x05, "Unknown(5)"},
+		{0xff, "Unknown(255)"},
+	}
+	for _, tt := range tests {
+		if have := TransactionTypeName(tt.txType); have != tt.want {
+			t.Errorf("type %d: name mismatch: have %q, want %q", tt.txType, have, tt.want)
+		}
+	}
+}
+
 func TestDecodeEmptyTypedTx(t *testing.T) {
 	input := []byte{0x80}
 	var tx Transaction
@@ -83,6 +104,59 @@ func TestDecodeEmptyTypedTx(t *testing.T) {
 	}
 }
 
+// Tests that the transaction hash is cached after the first computation, and that
+// signing or decoding a different transaction does not return a stale one.
+func TestTransactionHashCaching(t *testing.T) {
+	var (
+		signer = LatestSignerForChainID(big.NewInt(1))
+		key, _ = defaultTestKey()
+		tx     = NewTx(&DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1, To: &testAddr, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)})
+	)
+	hash := tx.Hash()
+	if allocs := testing.AllocsPerRun(1000, func() {
+		if tx.Hash() != hash {
+			t.Fatal("cached hash mismatch")
+		}
+	}); allocs != 0 {
+		t.Errorf("cached hash allocated: %v allocs/op", allocs)
+	}
+	// Signing yields a new transaction with a different hash
+	signed, err := SignTx(tx, signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Hash() == hash || tx.Hash() != hash {
+		t.Fatalf("signing hash mismatch: unsigned %x, signed %x, original %x", tx.Hash(), signed.Hash(), hash)
+	}
+	// Decoding into a transaction with populated caches must reset them
+	other, _ := crypto.GenerateKey()
+	reused, err := SignTx(NewTx(&LegacyTx{Nonce: 2, To: &testAddr, Gas: 21000, GasPrice: big.NewInt(1), Data: make([]byte, 64)}), signer, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reused.Hash()
+	reused.Size()
+	if _, err := Sender(signer, reused); err != nil {
+		t.Fatal(err)
+	}
+	blob, err := signed.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reused.UnmarshalBinary(blob); err != nil {
+		t.Fatal(err)
+	}
+	if reused.Hash() != signed.Hash() {
+		t.Errorf("decoded transaction hash mismatch: have %x, want %x", reused.Hash(), signed.Hash())
+	}
+	if from, err := Sender(signer, reused); err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("decoded transaction sender mismatch: have %x, %v", from, err)
+	}
+	if reused.Size() != uint64(len(blob)) {
+		t.Errorf("decoded transaction size mismatch: have %d, want %d", reused.Size(), len(blob))
+	}
+}
+
 func TestTransactionSigHash(t *testing.T) {
 	var homestead HomesteadSigner
 	if homestead.Hash(emptyTx) != common.HexToHash("c775b99e7ad12f50d819fcd602390467e28141316969f4b57f0626f74fe3b386") {
@@ -347,6 +421,64 @@ func TestTransactionCoding(t *testing.T) {
 	}
 }
 
+// TestTransactionNetworkRoundtrip tests that the network encoding of all the
+// supported transaction types (including blob sidecars) survives a roundtrip.
+func TestTransactionNetworkRoundtrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	var (
+		signer    = NewPragueSigner(common.Big1)
+		recipient = common.HexToAddress("095e7baea6a6c7c4c2dfeb977efac326af552d87")
+		accesses  = AccessList{{Address: recipient, StorageKeys: []common.Hash{{0}}}}
+	)
+	sidecarV1 := createEmptyBlobTxInner(true).Sidecar.Copy()
+	if err := sidecarV1.ToV1(); err != nil {
+		t.Fatalf("could not convert sidecar to v1: %v", err)
+	}
+	blobTxV1 := createEmptyBlobTxInner(true)
+	blobTxV1.Sidecar = sidecarV1
+
+	tests := []struct {
+		name   string
+		txdata TxData
+	}{
+		{"legacy", &LegacyTx{Nonce: 1, To: &recipient, Gas: 21000, GasPrice: big.NewInt(2), Data: []byte("abcdef")}},
+		{"accesslist", &AccessListTx{ChainID: big.NewInt(1), Nonce: 2, To: &recipient, Gas: 30000, GasPrice: big.NewInt(10), AccessList: accesses}},
+		{"dynamicfee", &DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 3, To: &recipient, Gas: 30000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(10), AccessList: accesses}},
+		{"blob-nosidecar", createEmptyBlobTxInner(false)},
+		{"blob-sidecar-v0", createEmptyBlobTxInner(true)},
+		{"blob-sidecar-v1", blobTxV1},
+	}
+	for _, tt := range tests {
+		tx, err := SignNewTx(key, signer, tt.txdata)
+		if err != nil {
+			t.Fatalf("%s: could not sign transaction: %v", tt.name, err)
+		}
+		blob, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: could not encode transaction: %v", tt.name, err)
+		}
+		parsed := new(Transaction)
+		if err := parsed.UnmarshalBinary(blob); err != nil {
+			t.Fatalf("%s: could not decode transaction: %v", tt.name, err)
+		}
+		if have, want := parsed.Hash(), tx.Hash(); have != want {
+			t.Errorf("%s: hash mismatch: have %x, want %x", tt.name, have, want)
+		}
+		if have, want := parsed.BlobHashes(), tx.BlobHashes(); !slices.Equal(have, want) {
+			t.Errorf("%s: blob hashes mismatch: have %x, want %x", tt.name, have, want)
+		}
+		if !TransactionDeepEquals(parsed, tx) {
+			t.Errorf("%s: sidecar mismatch after roundtrip", tt.name)
+		}
+		if have, want := parsed.Size(), uint64(len(blob)); have != want {
+			t.Errorf("%s: size mismatch: have %d, want %d", tt.name, have, want)
+		}
+	}
+}
+
 func TestLegacyTransaction_ConsistentV_LargeChainIds(t *testing.T) {
 	chainId := new(big.Int).SetUint64(13317435930671861669)
 
@@ -18,6 +18,9 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -35,6 +38,61 @@ type AccessTuple struct {
 	StorageKeys []common.Hash  `json:"storageKeys" gencodec:"required"`
 }
 
+// UnmarshalJSON parses an access list, accepting the tuples both in the standard
+// {"address", "storageKeys"} object format and in the [address, storageKeys]
+// array format used by some clients.
+func (al *AccessList) UnmarshalJSON(input []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*al = nil
+		return nil
+	}
+	list := make(AccessList, len(raw))
+	for i, entry := range raw {
+		if err := list[i].unmarshalLenientJSON(entry); err != nil {
+			return fmt.Errorf("invalid access list entry %d: %w", i, err)
+		}
+	}
+	*al = list
+	return nil
+}
+
+// unmarshalLenientJSON parses an access tuple either from the object or the
+// two-element array format.
+func (a *AccessTuple) unmarshalLenientJSON(input []byte) error {
+	input = bytes.TrimLeft(input, " \t\r\n")
+	if len(input) == 0 {
+		return errors.New("empty access tuple")
+	}
+	switch input[0] {
+	case '{':
+		return a.UnmarshalJSON(input)
+	case '[':
+		var pair []json.RawMessage
+		if err := json.Unmarshal(input, &pair); err != nil {
+			return err
+		}
+		if len(pair) != 2 {
+			return fmt.Errorf("access tuple array has %d elements, want 2", len(pair))
+		}
+		if err := json.Unmarshal(pair[0], &a.Address); err != nil {
+			return fmt.Errorf("invalid address: %w", err)
+		}
+		if err := json.Unmarshal(pair[1], &a.StorageKeys); err != nil {
+			return fmt.Errorf("invalid storage keys: %w", err)
+		}
+		if a.StorageKeys == nil {
+			return errors.New("missing storage keys")
+		}
+		return nil
+	default:
+		return errors.New("unrecognized access tuple format, want object or [address, storageKeys] array")
+	}
+}
+
 // StorageKeys returns the total number of storage keys in the access list.
 func (al AccessList) StorageKeys() int {
 	sum := 0
@@ -0,0 +1,121 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that access lists can be decoded from both the object and the array
+// tuple formats, and that malformed entries are rejected.
+func TestAccessListUnmarshalJSON(t *testing.T) {
+	want := AccessList{
+		{Address: common.Address{0x01}, StorageKeys: []common.Hash{{0x02}, {0x03}}},
+		{Address: common.Address{0x04}, StorageKeys: []common.Hash{}},
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  AccessList
+		fail  bool
+	}{
+		{
+			name:  "object",
+			input: `[{"address":"0x0100000000000000000000000000000000000000","storageKeys":["0x0200000000000000000000000000000000000000000000000000000000000000","0x0300000000000000000000000000000000000000000000000000000000000000"]},{"address":"0x0400000000000000000000000000000000000000","storageKeys":[]}]`,
+			want:  want,
+		},
+		{
+			name:  "array",
+			input: `[["0x0100000000000000000000000000000000000000",["0x0200000000000000000000000000000000000000000000000000000000000000","0x0300000000000000000000000000000000000000000000000000000000000000"]],["0x0400000000000000000000000000000000000000",[]]]`,
+			want:  want,
+		},
+		{
+			name:  "mixed",
+			input: `[ ["0x0100000000000000000000000000000000000000",["0x0200000000000000000000000000000000000000000000000000000000000000","0x0300000000000000000000000000000000000000000000000000000000000000"]], {"address":"0x0400000000000000000000000000000000000000","storageKeys":[]}]`,
+			want:  want,
+		},
+		{name: "empty", input: `[]`, want: AccessList{}},
+		{name: "null", input: `null`, want: nil},
+		{name: "not a list", input: `{}`, fail: true},
+		{name: "scalar entry", input: `["0x0100000000000000000000000000000000000000"]`, fail: true},
+		{name: "short array", input: `[["0x0100000000000000000000000000000000000000"]]`, fail: true},
+		{name: "long array", input: `[["0x0100000000000000000000000000000000000000",[],[]]]`, fail: true},
+		{name: "null keys", input: `[["0x0100000000000000000000000000000000000000",null]]`, fail: true},
+		{name: "bad address", input: `[["0x01",[]]]`, fail: true},
+		{name: "missing keys", input: `[{"address":"0x0100000000000000000000000000000000000000"}]`, fail: true},
+	}
+	for _, tt := range tests {
+		var have AccessList
+		err := json.Unmarshal([]byte(tt.input), &have)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", tt.name, have)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: failed to decode: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%s: access list mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+// Tests that access lists survive a JSON encoding roundtrip, including when
+// embedded into a transaction.
+func TestAccessListJSONRoundtrip(t *testing.T) {
+	list := AccessList{
+		{Address: common.Address{0x01}, StorageKeys: []common.Hash{{0x02}}},
+		{Address: common.Address{0x03}, StorageKeys: []common.Hash{}},
+	}
+	blob, err := json.Marshal(list)
+	if err != nil {
+		t.Fatalf("failed to encode access list: %v", err)
+	}
+	var dec AccessList
+	if err := json.Unmarshal(blob, &dec); err != nil {
+		t.Fatalf("failed to decode access list: %v", err)
+	}
+	if !reflect.DeepEqual(dec, list) {
+		t.Fatalf("access list mismatch: have %v, want %v", dec, list)
+	}
+	tx := NewTx(&AccessListTx{
+		ChainID:    common.Big1,
+		GasPrice:   common.Big1,
+		Value:      common.Big0,
+		AccessList: list,
+		V:          common.Big0,
+		R:          common.Big0,
+		S:          common.Big0,
+	})
+	if blob, err = json.Marshal(tx); err != nil {
+		t.Fatalf("failed to encode transaction: %v", err)
+	}
+	var dectx Transaction
+	if err := json.Unmarshal(blob, &dectx); err != nil {
+		t.Fatalf("failed to decode transaction: %v", err)
+	}
+	if !reflect.DeepEqual(dectx.AccessList(), list) {
+		t.Fatalf("transaction access list mismatch: have %v, want %v", dectx.AccessList(), list)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"slices"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -68,11 +69,19 @@ type BlobTx struct {
 }
 
 // BlobTxSidecar contains the blobs of a blob transaction.
+//
+// The sidecar is never RLP encoded directly: legacy (version 0) sidecars are
+// written without a version and versioned ones lead with it, so the encoders
+// pick the layout from Version and the decoders tell them apart by the kind
+// of the first list element. Version is hence not an optional rlp field (those
+// may only trail a struct), but old unversioned sidecars still decode as 0.
 type BlobTxSidecar struct {
 	Version     byte                 // Version
 	Blobs       []kzg4844.Blob       // Blobs needed by the blob pool
 	Commitments []kzg4844.Commitment // Commitments needed by the blob pool
 	Proofs      []kzg4844.Proof      // Proofs needed by the blob pool
+
+	hash atomic.Pointer[common.Hash] // Cached sidecar hash, reset on in-place updates
 }
 
 // NewBlobTxSidecar initialises the BlobTxSidecar object with the provided parameters.
@@ -85,6 +94,34 @@ func NewBlobTxSidecar(version byte, blobs []kzg4844.Blob, commitments []kzg4844.
 	}
 }
 
+// NewBlobTxSidecarFromBytes initialises a BlobTxSidecar object from raw blob
+// data, copying each blob into its fixed size representation. An error is
+// returned if any of the blobs is not exactly kzg4844 blob sized.
+func NewBlobTxSidecarFromBytes(version byte, blobBytes [][]byte, commitments []kzg4844.Commitment, proofs []kzg4844.Proof) (*BlobTxSidecar, error) {
+	blobs := make([]kzg4844.Blob, len(blobBytes))
+	for i, b := range blobBytes {
+		if len(b) != len(blobs[i]) {
+			return nil, fmt.Errorf("invalid blob %d length %d, want %d", i, len(b), len(blobs[i]))
+		}
+		copy(blobs[i][:], b)
+	}
+	return NewBlobTxSidecar(version, blobs, commitments, proofs), nil
+}
+
+// NumBlobs returns the number of blobs contained in the sidecar. It is safe to
+// call on a nil sidecar, in which case zero is returned.
+func (sc *BlobTxSidecar) NumBlobs() int {
+	if sc == nil {
+		return 0
+	}
+	return len(sc.Blobs)
+}
+
+// IsEmpty reports whether the sidecar is missing or does not carry any blobs.
+func (sc *BlobTxSidecar) IsEmpty() bool {
+	return sc.NumBlobs() == 0
+}
+
 // BlobHashes computes the blob hashes of the given blobs.
 func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
 	hasher := sha256.New()
@@ -95,14 +132,63 @@ func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
 	return h
 }
 
+// BlobAt returns the blob with index idx.
+func (sc *BlobTxSidecar) BlobAt(idx int) (*kzg4844.Blob, error) {
+	if idx < 0 || idx >= sc.NumBlobs() {
+		return nil, fmt.Errorf("blob out of bounds, index: %d, blobs: %d", idx, sc.NumBlobs())
+	}
+	return &sc.Blobs[idx], nil
+}
+
+// CommitmentAt returns the commitment for blob with index idx.
+func (sc *BlobTxSidecar) CommitmentAt(idx int) (kzg4844.Commitment, error) {
+	var n int
+	if sc != nil {
+		n = len(sc.Commitments)
+	}
+	if idx < 0 || idx >= n {
+		return kzg4844.Commitment{}, fmt.Errorf("commitment out of bounds, index: %d, commitments: %d", idx, n)
+	}
+	return sc.Commitments[idx], nil
+}
+
+// ProofAt returns the proof for blob with index idx.
+// This method is only valid for sidecars with version 0.
+func (sc *BlobTxSidecar) ProofAt(idx int) (kzg4844.Proof, error) {
+	var n int
+	if sc != nil {
+		n = len(sc.Proofs)
+	}
+	if idx < 0 || idx >= n {
+		return kzg4844.Proof{}, fmt.Errorf("proof out of bounds, index: %d, proofs: %d", idx, n)
+	}
+	if sc.Version != BlobSidecarVersion0 {
+		return kzg4844.Proof{}, fmt.Errorf("blob proof unsupported, version: %d", sc.Version)
+	}
+	return sc.Proofs[idx], nil
+}
+
+// ForEachBlob calls fn for every blob in the sidecar along with its commitment
+// and proof. It is only meaningful for version 0 sidecars carrying a single
+// proof per blob, and panics if the number of commitments or proofs does not
+// match the number of blobs.
+func (sc *BlobTxSidecar) ForEachBlob(fn func(idx int, blob *kzg4844.Blob, commitment kzg4844.Commitment, proof kzg4844.Proof)) {
+	if len(sc.Commitments) != len(sc.Blobs) || len(sc.Proofs) != len(sc.Blobs) {
+		panic(fmt.Sprintf("inconsistent blob sidecar: %d blobs, %d commitments, %d proofs", len(sc.Blobs), len(sc.Commitments), len(sc.Proofs)))
+	}
+	for i := range sc.Blobs {
+		fn(i, &sc.Blobs[i], sc.Commitments[i], sc.Proofs[i])
+	}
+}
+
 // CellProofsAt returns the cell proofs for blob with index idx.
 // This method is only valid for sidecars with version 1.
 func (sc *BlobTxSidecar) CellProofsAt(idx int) ([]kzg4844.Proof, error) {
 	if sc.Version != BlobSidecarVersion1 {
 		return nil, fmt.Errorf("cell proof unsupported, version: %d", sc.Version)
 	}
-	if idx < 0 || idx >= len(sc.Blobs) {
-		return nil, fmt.Errorf("cell proof out of bounds, index: %d, blobs: %d", idx, len(sc.Blobs))
+	if idx < 0 || idx >= sc.NumBlobs() {
+		return nil, fmt.Errorf("cell proof out of bounds, index: %d, blobs: %d", idx, sc.NumBlobs())
 	}
 	index := idx * kzg4844.CellProofsPerBlob
 	if len(sc.Proofs) < index+kzg4844.CellProofsPerBlob {
@@ -117,7 +203,7 @@ func (sc *BlobTxSidecar) ToV1() error {
 		return nil
 	}
 	if sc.Version == BlobSidecarVersion0 {
-		proofs := make([]kzg4844.Proof, 0, len(sc.Blobs)*kzg4844.CellProofsPerBlob)
+		proofs := make([]kzg4844.Proof, 0, sc.NumBlobs()*kzg4844.CellProofsPerBlob)
 		for _, blob := range sc.Blobs {
 			cellProofs, err := kzg4844.ComputeCellProofs(&blob)
 			if err != nil {
@@ -127,24 +213,107 @@ func (sc *BlobTxSidecar) ToV1() error {
 		}
 		sc.Version = BlobSidecarVersion1
 		sc.Proofs = proofs
+		sc.hash.Store(nil)
 	}
 	return nil
 }
 
-// encodedSize computes the RLP size of the sidecar elements. This does NOT return the
-// encoded size of the BlobTxSidecar, it's just a helper for tx.Size().
-func (sc *BlobTxSidecar) encodedSize() uint64 {
-	var blobs, commitments, proofs uint64
+// Hash returns the sha256 hash of the concatenated blobs, commitments and proofs
+// of the sidecar, which can be used to deduplicate sidecars in caches. The hash
+// is computed on first use and cached afterwards, so the fields must not be
+// modified directly once it has been requested.
+func (sc *BlobTxSidecar) Hash() common.Hash {
+	if hash := sc.hash.Load(); hash != nil {
+		return *hash
+	}
+	hasher := sha256.New()
 	for i := range sc.Blobs {
-		blobs += rlp.BytesSize(sc.Blobs[i][:])
+		hasher.Write(sc.Blobs[i][:])
 	}
 	for i := range sc.Commitments {
-		commitments += rlp.BytesSize(sc.Commitments[i][:])
+		hasher.Write(sc.Commitments[i][:])
 	}
 	for i := range sc.Proofs {
-		proofs += rlp.BytesSize(sc.Proofs[i][:])
+		hasher.Write(sc.Proofs[i][:])
+	}
+	var h common.Hash
+	hasher.Sum(h[:0])
+	sc.hash.Store(&h)
+	return h
+}
+
+// ComputeCommitments computes the KZG commitments of the blobs in the sidecar,
+// in the same order as the blobs.
+func (sc *BlobTxSidecar) ComputeCommitments() ([]kzg4844.Commitment, error) {
+	commitments := make([]kzg4844.Commitment, len(sc.Blobs))
+	for i := range sc.Blobs {
+		commitment, err := kzg4844.BlobToCommitment(&sc.Blobs[i])
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %v", i, err)
+		}
+		commitments[i] = commitment
+	}
+	return commitments, nil
+}
+
+// FillCommitmentsAndProofs computes the commitments of the blobs in the sidecar
+// and then the proofs matching the sidecar version, replacing any existing ones.
+func (sc *BlobTxSidecar) FillCommitmentsAndProofs() error {
+	commitments, err := sc.ComputeCommitments()
+	if err != nil {
+		return err
+	}
+	var proofs []kzg4844.Proof
+	switch sc.Version {
+	case BlobSidecarVersion0:
+		proofs = make([]kzg4844.Proof, 0, len(sc.Blobs))
+		for i := range sc.Blobs {
+			proof, err := kzg4844.ComputeBlobProof(&sc.Blobs[i], commitments[i])
+			if err != nil {
+				return fmt.Errorf("blob %d: %v", i, err)
+			}
+			proofs = append(proofs, proof)
+		}
+	case BlobSidecarVersion1:
+		proofs = make([]kzg4844.Proof, 0, len(sc.Blobs)*kzg4844.CellProofsPerBlob)
+		for i := range sc.Blobs {
+			cellProofs, err := kzg4844.ComputeCellProofs(&sc.Blobs[i])
+			if err != nil {
+				return fmt.Errorf("blob %d: %v", i, err)
+			}
+			proofs = append(proofs, cellProofs...)
+		}
+	default:
+		return fmt.Errorf("unknown sidecar version %d", sc.Version)
 	}
-	return rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs)
+	sc.Commitments = commitments
+	sc.Proofs = proofs
+	sc.hash.Store(nil)
+	return nil
+}
+
+// TotalSize returns the number of bytes the blobs, commitments and proofs of the
+// sidecar occupy, including the RLP headers of the three lists. It is meant for
+// memory accounting and does not include the version field or the outer list.
+func (sc *BlobTxSidecar) TotalSize() int {
+	var (
+		blobs       = uint64(len(sc.Blobs)) * rlpBytesSize(kzg4844.BlobSize)
+		commitments = uint64(len(sc.Commitments)) * rlpBytesSize(kzg4844.CommitmentSize)
+		proofs      = uint64(len(sc.Proofs)) * rlpBytesSize(kzg4844.ProofSize)
+	)
+	return int(rlp.ListSize(blobs) + rlp.ListSize(commitments) + rlp.ListSize(proofs))
+}
+
+// rlpBytesSize returns the RLP encoded size of a byte string of length n, where
+// n is larger than one (i.e. the string is never encoded as a single byte).
+func rlpBytesSize(n uint64) uint64 {
+	return rlp.ListSize(n) // strings and lists share the same header layout
+}
+
+// encodedSize computes the RLP size of the sidecar elements. This does NOT return the
+// encoded size of the BlobTxSidecar, it's just a helper for tx.Size().
+func (sc *BlobTxSidecar) encodedSize() uint64 {
+	return uint64(sc.TotalSize())
 }
 
 // ValidateBlobCommitmentHashes checks whether the given hashes correspond to the
@@ -163,6 +332,53 @@ func (sc *BlobTxSidecar) ValidateBlobCommitmentHashes(hashes []common.Hash) erro
 	return nil
 }
 
+// Verify checks that the sidecar carries exactly the blobs committed to by the
+// given blob hashes: the number of blobs, commitments and proofs are checked
+// first, then the commitments against the hashes and lastly the KZG proofs, as
+// per the sidecar version.
+func (sc *BlobTxSidecar) Verify(blobHashes []common.Hash) error {
+	if len(sc.Blobs) != len(blobHashes) {
+		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sc.Blobs), len(blobHashes))
+	}
+	if err := sc.ValidateBlobCommitmentHashes(blobHashes); err != nil {
+		return err
+	}
+	switch sc.Version {
+	case BlobSidecarVersion0:
+		if len(sc.Proofs) != len(sc.Blobs) {
+			return fmt.Errorf("invalid number of %d blob proofs expected %d", len(sc.Proofs), len(sc.Blobs))
+		}
+		for i := range sc.Blobs {
+			if err := kzg4844.VerifyBlobProof(&sc.Blobs[i], sc.Commitments[i], sc.Proofs[i]); err != nil {
+				return fmt.Errorf("blob %d: invalid proof: %v", i, err)
+			}
+		}
+	case BlobSidecarVersion1:
+		if len(sc.Proofs) != len(sc.Blobs)*kzg4844.CellProofsPerBlob {
+			return fmt.Errorf("invalid number of %d blob proofs expected %d", len(sc.Proofs), len(sc.Blobs)*kzg4844.CellProofsPerBlob)
+		}
+		if err := kzg4844.VerifyCellProofs(sc.Blobs, sc.Commitments, sc.Proofs); err != nil {
+			return fmt.Errorf("invalid cell proofs: %v", err)
+		}
+	default:
+		return fmt.Errorf("unknown sidecar version %d", sc.Version)
+	}
+	return nil
+}
+
+// HasValidFieldElements checks whether all the field elements of all the blobs
+// are canonical, i.e. below the BLS12-381 scalar modulus. If not, the indices of
+// the first offending blob and of the field element within it are returned too,
+// otherwise both indices are -1.
+func (sc *BlobTxSidecar) HasValidFieldElements() (bool, int, int) {
+	for i := range sc.Blobs {
+		if elem := kzg4844.FirstInvalidFieldElement(&sc.Blobs[i]); elem >= 0 {
+			return false, i, elem
+		}
+	}
+	return true, -1, -1
+}
+
 // Copy returns a deep-copied BlobTxSidecar object.
 func (sc *BlobTxSidecar) Copy() *BlobTxSidecar {
 	return &BlobTxSidecar{
@@ -176,6 +392,69 @@ func (sc *BlobTxSidecar) Copy() *BlobTxSidecar {
 	}
 }
 
+// blobSidecarV0 and blobSidecarV1 are the standalone encodings of the versioned
+// sidecars, laid out the same way as the sidecar fields following the tx in the
+// network encoding of a blob transaction.
+type blobSidecarV0 struct {
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+type blobSidecarV1 struct {
+	Version     byte
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+// MarshalBinary returns the standalone RLP encoding of the sidecar. Its list
+// elements are identical to the ones trailing the transaction in the network
+// encoding of a blob transaction carrying the sidecar.
+func (sc *BlobTxSidecar) MarshalBinary() ([]byte, error) {
+	switch sc.Version {
+	case BlobSidecarVersion0:
+		return rlp.EncodeToBytes(&blobSidecarV0{Blobs: sc.Blobs, Commitments: sc.Commitments, Proofs: sc.Proofs})
+	case BlobSidecarVersion1:
+		return rlp.EncodeToBytes(&blobSidecarV1{Version: sc.Version, Blobs: sc.Blobs, Commitments: sc.Commitments, Proofs: sc.Proofs})
+	default:
+		return nil, errors.New("unsupported sidecar version")
+	}
+}
+
+// UnmarshalBinary decodes the standalone RLP encoding of a sidecar, as created
+// by MarshalBinary.
+func (sc *BlobTxSidecar) UnmarshalBinary(data []byte) error {
+	// Legacy sidecars start with the list of blobs, versioned ones with the
+	// version byte, same as in the network encoding of the transaction.
+	content, _, err := rlp.SplitList(data)
+	if err != nil {
+		return err
+	}
+	kind, _, _, err := rlp.Split(content)
+	if err != nil {
+		return err
+	}
+	if kind == rlp.List {
+		var dec blobSidecarV0
+		if err := rlp.DecodeBytes(data, &dec); err != nil {
+			return err
+		}
+		sc.Version, sc.Blobs, sc.Commitments, sc.Proofs = BlobSidecarVersion0, dec.Blobs, dec.Commitments, dec.Proofs
+	} else {
+		var dec blobSidecarV1
+		if err := rlp.DecodeBytes(data, &dec); err != nil {
+			return err
+		}
+		if dec.Version != BlobSidecarVersion1 {
+			return fmt.Errorf("unsupported blob sidecar version %d", dec.Version)
+		}
+		sc.Version, sc.Blobs, sc.Commitments, sc.Proofs = dec.Version, dec.Blobs, dec.Commitments, dec.Proofs
+	}
+	sc.hash.Store(nil)
+	return nil
+}
+
 // blobTxWithBlobs represents blob tx with its corresponding sidecar.
 // This is an interface because sidecars are versioned.
 type blobTxWithBlobs interface {
@@ -277,6 +556,83 @@ func (tx *BlobTx) copy() TxData {
 	return cpy
 }
 
+// SanityCheck verifies the stateless consistency of the transaction fields: the
+// chain id and blob fee cap are set, the tip does not exceed the fee cap, there
+// is at least one blob hash of a known version and, if attached, the sidecar
+// matches the blob hashes.
+//
+// It does not verify the KZG proofs, nor any limits dependent on the fork.
+func (tx *BlobTx) SanityCheck() error {
+	if tx.ChainID == nil {
+		return errors.New("missing chain id")
+	}
+	if tx.GasFeeCap == nil || tx.GasTipCap == nil {
+		return errors.New("missing gas fee or tip cap")
+	}
+	if tx.GasFeeCap.Lt(tx.GasTipCap) {
+		return fmt.Errorf("max priority fee per gas higher than max fee per gas: tip %v, fee cap %v", tx.GasTipCap, tx.GasFeeCap)
+	}
+	if tx.BlobFeeCap == nil || tx.BlobFeeCap.IsZero() {
+		return errors.New("zero blob fee cap")
+	}
+	return tx.checkBlobs()
+}
+
+// checkBlobs verifies that the transaction carries at least one blob hash, all
+// of a known version, and if a sidecar is attached, that its blobs, commitments
+// and proof counts match the hashes.
+func (tx *BlobTx) checkBlobs() error {
+	if len(tx.BlobHashes) == 0 {
+		return errors.New("missing blob hashes")
+	}
+	for i, hash := range tx.BlobHashes {
+		if err := kzg4844.ValidateBlobHashVersion(hash); err != nil {
+			return fmt.Errorf("blob %d: %v", i, err)
+		}
+	}
+	if sc := tx.Sidecar; sc != nil {
+		if len(sc.Blobs) != len(tx.BlobHashes) {
+			return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sc.Blobs), len(tx.BlobHashes))
+		}
+		if err := sc.ValidateBlobCommitmentHashes(tx.BlobHashes); err != nil {
+			return err
+		}
+		var proofs int
+		switch sc.Version {
+		case BlobSidecarVersion0:
+			proofs = len(sc.Blobs)
+		case BlobSidecarVersion1:
+			proofs = len(sc.Blobs) * kzg4844.CellProofsPerBlob
+		default:
+			return fmt.Errorf("unknown sidecar version %d", sc.Version)
+		}
+		if len(sc.Proofs) != proofs {
+			return fmt.Errorf("invalid number of %d blob proofs, expected %d", len(sc.Proofs), proofs)
+		}
+	}
+	return nil
+}
+
+// Validate checks the structural invariants of a blob transaction which can be
+// verified without signing it or running any KZG operations, returning a
+// descriptive error if any of them is violated. The chain ID may be left unset,
+// it is filled in by the signer.
+func (tx *BlobTx) Validate() error {
+	if tx.GasTipCap == nil {
+		return fmt.Errorf("%w: missing gas tip cap", ErrInvalidBlobTx)
+	}
+	if tx.GasFeeCap == nil {
+		return fmt.Errorf("%w: missing gas fee cap", ErrInvalidBlobTx)
+	}
+	if tx.BlobFeeCap == nil {
+		return fmt.Errorf("%w: missing blob fee cap", ErrInvalidBlobTx)
+	}
+	if err := tx.checkBlobs(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBlobTx, err)
+	}
+	return nil
+}
+
 // accessors for innerTx.
 func (tx *BlobTx) txType() byte           { return BlobTxType }
 func (tx *BlobTx) chainID() *big.Int      { return tx.ChainID.ToBig() }
@@ -401,18 +757,22 @@ func (tx *BlobTx) decode(input []byte) error {
 	if err != nil {
 		return err
 	}
-	var payload blobTxWithBlobs
+	// The sidecar is taken from the pool if recycling is enabled, decoding into
+	// its recycled buffers.
+	var (
+		sc      = DefaultBlobSidecarPool.decodeSidecar()
+		payload blobTxWithBlobs
+	)
 	if secondElemKind == rlp.List {
 		// No version byte: blob sidecar v0.
-		payload = new(blobTxWithBlobsV0)
+		payload = &blobTxWithBlobsV0{Blobs: sc.Blobs, Commitments: sc.Commitments, Proofs: sc.Proofs}
 	} else {
 		// It has a version byte. Decode as v1, version is checked by assign()
-		payload = new(blobTxWithBlobsV1)
+		payload = &blobTxWithBlobsV1{Blobs: sc.Blobs, Commitments: sc.Commitments, Proofs: sc.Proofs}
 	}
 	if err := rlp.DecodeBytes(input, payload); err != nil {
 		return err
 	}
-	sc := new(BlobTxSidecar)
 	if err := payload.assign(sc); err != nil {
 		return err
 	}
@@ -0,0 +1,78 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// BlobSidecarPool is a free list of blob sidecars, recycling their blob,
+// commitment and proof buffers across transactions. A single blob weighs 128KB,
+// so reusing the buffers of short-lived sidecars takes considerable pressure off
+// the garbage collector when many blob transactions are received.
+//
+// The zero value is ready for use.
+type BlobSidecarPool struct {
+	pool    sync.Pool
+	enabled atomic.Bool // Whether decoding takes its sidecars from the pool
+}
+
+// DefaultBlobSidecarPool is the sidecar pool used when decoding blob transactions
+// in their network encoding, once enabled.
+var DefaultBlobSidecarPool = new(BlobSidecarPool)
+
+// Enable makes the decoding of blob transactions take their sidecars from the
+// pool. It is meant to be called by whoever releases sidecars into the pool;
+// until then, decoding allocates fresh sidecars, so the ones retained long-term
+// never pin oversized recycled buffers.
+func (p *BlobSidecarPool) Enable() {
+	p.enabled.Store(true)
+}
+
+// decodeSidecar returns the sidecar to decode a blob transaction into, taken
+// from the pool if enabled.
+func (p *BlobSidecarPool) decodeSidecar() *BlobTxSidecar {
+	if p.enabled.Load() {
+		return p.Get()
+	}
+	return new(BlobTxSidecar)
+}
+
+// Get returns an empty sidecar, reusing the buffers of a previously released one
+// if available.
+func (p *BlobSidecarPool) Get() *BlobTxSidecar {
+	if sc, ok := p.pool.Get().(*BlobTxSidecar); ok {
+		return sc
+	}
+	return new(BlobTxSidecar)
+}
+
+// Put clears the sidecar and releases it into the pool for reuse. The sidecar,
+// and any transaction it is attached to, must not be used afterwards.
+func (p *BlobSidecarPool) Put(sc *BlobTxSidecar) {
+	if sc == nil {
+		return
+	}
+	sc.Version = 0
+	sc.Blobs = sc.Blobs[:0]
+	sc.Commitments = sc.Commitments[:0]
+	sc.Proofs = sc.Proofs[:0]
+	sc.hash.Store(nil)
+
+	p.pool.Put(sc)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// Tests that released sidecars are cleared, and that transactions decoded into
+// recycled buffers are identical to freshly decoded ones.
+func TestBlobSidecarPool(t *testing.T) {
+	var pool BlobSidecarPool
+
+	sc := NewBlobTxSidecar(BlobSidecarVersion1, []kzg4844.Blob{*emptyBlob}, []kzg4844.Commitment{emptyBlobCommit}, []kzg4844.Proof{emptyBlobProof})
+	sc.Hash()
+	pool.Put(sc)
+
+	if sc.Version != 0 || len(sc.Blobs) != 0 || len(sc.Commitments) != 0 || len(sc.Proofs) != 0 || sc.hash.Load() != nil {
+		t.Fatalf("released sidecar not cleared: %+v", sc)
+	}
+	if cap(sc.Blobs) != 1 {
+		t.Errorf("released sidecar blob buffer dropped")
+	}
+	if got := pool.Get(); len(got.Blobs) != 0 || got.Version != 0 {
+		t.Errorf("pooled sidecar not empty: %+v", got)
+	}
+	pool.Put(nil) // should not panic
+
+	// Decode a blob transaction, recycle its sidecar and decode another one,
+	// ensuring the reused buffers don't leak stale data
+	DefaultBlobSidecarPool.enabled.Store(true)
+	defer DefaultBlobSidecarPool.enabled.Store(false)
+
+	blob, _ := kzg4844.NewRandomBlob()
+	first := NewTx(createEmptyBlobTxInner(true))
+	second := NewTx(createEmptyBlobTxInner(false)).WithBlobTxSidecar(NewBlobTxSidecar(BlobSidecarVersion0, []kzg4844.Blob{blob}, []kzg4844.Commitment{{0x01}}, []kzg4844.Proof{{0x02}}))
+
+	for i := 0; i < 2; i++ {
+		for _, want := range []*Transaction{first, second} {
+			enc, err := want.MarshalBinary()
+			if err != nil {
+				t.Fatalf("failed to encode transaction: %v", err)
+			}
+			have := new(Transaction)
+			if err := have.UnmarshalBinary(enc); err != nil {
+				t.Fatalf("failed to decode transaction: %v", err)
+			}
+			if !TransactionDeepEquals(have, want) {
+				t.Fatalf("round %d: decoded transaction mismatch", i)
+			}
+			DefaultBlobSidecarPool.Put(have.BlobTxSidecar())
+		}
+	}
+}
+
+// Tests that decoding does not take sidecars from the pool unless enabled.
+func TestBlobSidecarPoolDisabled(t *testing.T) {
+	tx := NewTx(createEmptyBlobTxInner(true))
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %v", err)
+	}
+	released := NewBlobTxSidecar(BlobSidecarVersion0, make([]kzg4844.Blob, 0, 8), nil, nil)
+	DefaultBlobSidecarPool.Put(released)
+
+	have := new(Transaction)
+	if err := have.UnmarshalBinary(enc); err != nil {
+		t.Fatalf("failed to decode transaction: %v", err)
+	}
+	if have.BlobTxSidecar() == released {
+		t.Error("sidecar taken from the disabled pool")
+	}
+}
@@ -17,12 +17,19 @@
 package types
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/holiman/uint256"
 )
 
@@ -74,6 +81,594 @@ func TestBlobTxSize(t *testing.T) {
 	}
 }
 
+// This test verifies the blob counting helpers of the sidecar, including on a
+// nil receiver.
+func TestBlobTxSidecarNumBlobs(t *testing.T) {
+	var nilSidecar *BlobTxSidecar
+	if n := nilSidecar.NumBlobs(); n != 0 || !nilSidecar.IsEmpty() {
+		t.Errorf("nil sidecar: have %d blobs, empty %v", n, nilSidecar.IsEmpty())
+	}
+	empty := NewBlobTxSidecar(BlobSidecarVersion0, nil, nil, nil)
+	if n := empty.NumBlobs(); n != 0 || !empty.IsEmpty() {
+		t.Errorf("empty sidecar: have %d blobs, empty %v", n, empty.IsEmpty())
+	}
+	sidecar := createEmptyBlobTxInner(true).Sidecar
+	if n := sidecar.NumBlobs(); n != 1 || sidecar.IsEmpty() {
+		t.Errorf("populated sidecar: have %d blobs, empty %v", n, sidecar.IsEmpty())
+	}
+}
+
+// This test verifies that the total size of a sidecar matches the RLP encoding
+// of its blob, commitment and proof lists.
+func TestBlobTxSidecarTotalSize(t *testing.T) {
+	tests := []*BlobTxSidecar{
+		NewBlobTxSidecar(BlobSidecarVersion0, nil, nil, nil),
+		NewBlobTxSidecar(BlobSidecarVersion0, []kzg4844.Blob{*emptyBlob}, []kzg4844.Commitment{emptyBlobCommit}, []kzg4844.Proof{emptyBlobProof}),
+		NewBlobTxSidecar(BlobSidecarVersion1, []kzg4844.Blob{*emptyBlob, *emptyBlob}, []kzg4844.Commitment{emptyBlobCommit, emptyBlobCommit}, make([]kzg4844.Proof, 2*kzg4844.CellProofsPerBlob)),
+	}
+	for i, sc := range tests {
+		var want int
+		for _, list := range []any{sc.Blobs, sc.Commitments, sc.Proofs} {
+			enc, err := rlp.EncodeToBytes(list)
+			if err != nil {
+				t.Fatalf("test %d: failed to encode list: %v", i, err)
+			}
+			want += len(enc)
+		}
+		if have := sc.TotalSize(); have != want {
+			t.Errorf("test %d: total size mismatch: have %d, want %d", i, have, want)
+		}
+	}
+}
+
+// This test verifies that sidecars round-trip through their standalone encoding,
+// and that it matches the sidecar fields embedded in the network encoding of the
+// transaction.
+func TestBlobTxSidecarMarshalBinary(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	for _, version := range []byte{BlobSidecarVersion0, BlobSidecarVersion1} {
+		tx := createEmptyBlobTx(key, true)
+		sidecar := tx.BlobTxSidecar().Copy()
+		if version == BlobSidecarVersion1 {
+			if err := sidecar.ToV1(); err != nil {
+				t.Fatalf("version %d: failed to convert sidecar: %v", version, err)
+			}
+			tx = tx.WithBlobTxSidecar(sidecar)
+		}
+		enc, err := sidecar.MarshalBinary()
+		if err != nil {
+			t.Fatalf("version %d: failed to encode sidecar: %v", version, err)
+		}
+		dec := new(BlobTxSidecar)
+		if err := dec.UnmarshalBinary(enc); err != nil {
+			t.Fatalf("version %d: failed to decode sidecar: %v", version, err)
+		}
+		if dec.Hash() != sidecar.Hash() || dec.Version != sidecar.Version || len(dec.Proofs) != len(sidecar.Proofs) {
+			t.Errorf("version %d: round trip mismatch", version)
+		}
+		// Strip the tx from the network encoding and compare the remainder
+		netenc, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("version %d: failed to encode tx: %v", version, err)
+		}
+		content, _, err := rlp.SplitList(netenc[1:])
+		if err != nil {
+			t.Fatalf("version %d: failed to split tx: %v", version, err)
+		}
+		_, _, embedded, err := rlp.Split(content)
+		if err != nil {
+			t.Fatalf("version %d: failed to split tx payload: %v", version, err)
+		}
+		standalone, _, _ := rlp.SplitList(enc)
+		if !bytes.Equal(standalone, embedded) {
+			t.Errorf("version %d: standalone encoding differs from the embedded sidecar", version)
+		}
+	}
+	if err := new(BlobTxSidecar).UnmarshalBinary([]byte{0xc1, 0x02}); err == nil {
+		t.Error("unsupported sidecar version accepted")
+	}
+}
+
+// This test verifies that sidecars can be constructed from raw blob data, and
+// that blobs of the wrong size are rejected.
+func TestNewBlobTxSidecarFromBytes(t *testing.T) {
+	blob := make([]byte, len(kzg4844.Blob{}))
+	blob[0], blob[len(blob)-1] = 0x01, 0x02
+
+	sidecar, err := NewBlobTxSidecarFromBytes(BlobSidecarVersion0, [][]byte{blob}, []kzg4844.Commitment{emptyBlobCommit}, []kzg4844.Proof{emptyBlobProof})
+	if err != nil {
+		t.Fatalf("failed to create sidecar: %v", err)
+	}
+	if len(sidecar.Blobs) != 1 || !bytes.Equal(sidecar.Blobs[0][:], blob) {
+		t.Errorf("blob mismatch")
+	}
+	if sidecar.Commitments[0] != emptyBlobCommit || sidecar.Proofs[0] != emptyBlobProof {
+		t.Errorf("commitment or proof mismatch")
+	}
+	// Ensure the blob data is copied rather than aliased
+	blob[0] = 0xff
+	if sidecar.Blobs[0][0] != 0x01 {
+		t.Errorf("blob aliases the source data")
+	}
+	for _, size := range []int{0, len(blob) - 1, len(blob) + 1} {
+		if _, err := NewBlobTxSidecarFromBytes(BlobSidecarVersion0, [][]byte{blob, make([]byte, size)}, nil, nil); err == nil {
+			t.Errorf("blob of size %d accepted", size)
+		}
+	}
+}
+
+// This test verifies that sidecars in the legacy network encoding, which predates
+// the version field, still decode as version 0 and re-encode in the same format.
+func TestBlobTxSidecarLegacyEncoding(t *testing.T) {
+	inner := createEmptyBlobTxInner(false)
+	sidecar := NewBlobTxSidecar(BlobSidecarVersion0, []kzg4844.Blob{*emptyBlob}, []kzg4844.Commitment{emptyBlobCommit}, []kzg4844.Proof{emptyBlobProof})
+
+	// Assemble the legacy [tx, blobs, commitments, proofs] payload by hand
+	payload, err := rlp.EncodeToBytes([]any{inner, sidecar.Blobs, sidecar.Commitments, sidecar.Proofs})
+	if err != nil {
+		t.Fatalf("failed to encode legacy payload: %v", err)
+	}
+	legacy := append([]byte{BlobTxType}, payload...)
+
+	var tx Transaction
+	if err := tx.UnmarshalBinary(legacy); err != nil {
+		t.Fatalf("failed to decode legacy sidecar: %v", err)
+	}
+	sc := tx.BlobTxSidecar()
+	if sc == nil {
+		t.Fatal("sidecar missing after decoding")
+	}
+	if sc.Version != BlobSidecarVersion0 {
+		t.Errorf("sidecar version mismatch: have %d, want %d", sc.Version, BlobSidecarVersion0)
+	}
+	if len(sc.Blobs) != 1 || sc.Commitments[0] != emptyBlobCommit || sc.Proofs[0] != emptyBlobProof {
+		t.Errorf("sidecar content mismatch")
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to re-encode transaction: %v", err)
+	}
+	if !bytes.Equal(enc, legacy) {
+		t.Errorf("re-encoded transaction mismatch:\nhave %x\nwant %x", enc, legacy)
+	}
+}
+
+// This test verifies that the sidecar blob iterator visits the matching blob,
+// commitment and proof triplets, and rejects inconsistent sidecars.
+func TestBlobTxSidecarForEachBlob(t *testing.T) {
+	blob, _ := kzg4844.NewRandomBlob()
+	sidecar := NewBlobTxSidecar(BlobSidecarVersion0, []kzg4844.Blob{*emptyBlob, blob}, nil, nil)
+	if err := sidecar.FillCommitmentsAndProofs(); err != nil {
+		t.Fatalf("failed to fill sidecar: %v", err)
+	}
+	var visited int
+	sidecar.ForEachBlob(func(i int, blob *kzg4844.Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) {
+		if blob != &sidecar.Blobs[i] || commitment != sidecar.Commitments[i] || proof != sidecar.Proofs[i] {
+			t.Errorf("blob %d: mismatching triplet", i)
+		}
+		visited++
+	})
+	if visited != 2 {
+		t.Errorf("visited blob count mismatch: have %d, want 2", visited)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("inconsistent sidecar iterated without panic")
+		}
+	}()
+	sidecar.Proofs = sidecar.Proofs[:1]
+	sidecar.ForEachBlob(func(int, *kzg4844.Blob, kzg4844.Commitment, kzg4844.Proof) {})
+}
+
+// This test verifies that the indexed sidecar accessors return the requested
+// items and reject out-of-bounds indices instead of panicking.
+func TestBlobTxSidecarAccessors(t *testing.T) {
+	blob, _ := kzg4844.NewRandomBlob()
+	sidecar := NewBlobTxSidecar(BlobSidecarVersion0, []kzg4844.Blob{*emptyBlob, blob}, nil, nil)
+	if err := sidecar.FillCommitmentsAndProofs(); err != nil {
+		t.Fatalf("failed to fill sidecar: %v", err)
+	}
+	for i := range sidecar.Blobs {
+		if b, err := sidecar.BlobAt(i); err != nil || b != &sidecar.Blobs[i] {
+			t.Errorf("blob %d: have %p, %v, want %p", i, b, err, &sidecar.Blobs[i])
+		}
+		if c, err := sidecar.CommitmentAt(i); err != nil || c != sidecar.Commitments[i] {
+			t.Errorf("commitment %d: mismatch, err %v", i, err)
+		}
+		if p, err := sidecar.ProofAt(i); err != nil || p != sidecar.Proofs[i] {
+			t.Errorf("proof %d: mismatch, err %v", i, err)
+		}
+	}
+	for _, i := range []int{-1, 2} {
+		if _, err := sidecar.BlobAt(i); err == nil {
+			t.Errorf("blob %d: out-of-bounds access accepted", i)
+		}
+		if _, err := sidecar.CommitmentAt(i); err == nil {
+			t.Errorf("commitment %d: out-of-bounds access accepted", i)
+		}
+		if _, err := sidecar.ProofAt(i); err == nil {
+			t.Errorf("proof %d: out-of-bounds access accepted", i)
+		}
+	}
+	// A truncated commitment list must be caught even if the blob is present
+	sidecar.Commitments = sidecar.Commitments[:1]
+	if _, err := sidecar.CommitmentAt(1); err == nil {
+		t.Error("missing commitment accepted")
+	}
+	// Version 1 sidecars carry cell proofs, which are not addressable per blob
+	sidecar.Version = BlobSidecarVersion1
+	if _, err := sidecar.ProofAt(0); err == nil {
+		t.Error("blob proof of version 1 sidecar accepted")
+	}
+	// Nil sidecars have nothing to access
+	var empty *BlobTxSidecar
+	if _, err := empty.BlobAt(0); err == nil {
+		t.Error("blob of nil sidecar accepted")
+	}
+	if _, err := empty.CommitmentAt(0); err == nil {
+		t.Error("commitment of nil sidecar accepted")
+	}
+	if _, err := empty.ProofAt(0); err == nil {
+		t.Error("proof of nil sidecar accepted")
+	}
+}
+
+// This test verifies the sidecar hash covers all the blob data and is kept in
+// sync with in-place conversions.
+func TestBlobTxSidecarHash(t *testing.T) {
+	sidecar := NewBlobTxSidecar(BlobSidecarVersion0, []kzg4844.Blob{*emptyBlob}, []kzg4844.Commitment{emptyBlobCommit}, []kzg4844.Proof{emptyBlobProof})
+
+	hasher := sha256.New()
+	hasher.Write(emptyBlob[:])
+	hasher.Write(emptyBlobCommit[:])
+	hasher.Write(emptyBlobProof[:])
+	want := common.BytesToHash(hasher.Sum(nil))
+
+	if have := sidecar.Hash(); have != want {
+		t.Fatalf("sidecar hash mismatch: have %x, want %x", have, want)
+	}
+	if have := sidecar.Copy().Hash(); have != want {
+		t.Errorf("copied sidecar hash mismatch: have %x, want %x", have, want)
+	}
+	mutated := sidecar.Copy()
+	mutated.Blobs[0][0] = 0x01
+	if mutated.Hash() == want {
+		t.Errorf("mutated sidecar hash unchanged")
+	}
+	if err := sidecar.ToV1(); err != nil {
+		t.Fatalf("failed to convert sidecar: %v", err)
+	}
+	if sidecar.Hash() == want {
+		t.Errorf("converted sidecar hash unchanged")
+	}
+}
+
+// This test verifies that the sidecar commitments and proofs can be computed
+// from the blobs alone for all sidecar versions.
+func TestBlobTxSidecarFillCommitmentsAndProofs(t *testing.T) {
+	blob, _ := kzg4844.NewRandomBlob()
+	blobs := []kzg4844.Blob{*emptyBlob, blob}
+
+	sidecar := NewBlobTxSidecar(BlobSidecarVersion0, blobs, nil, nil)
+	commitments, err := sidecar.ComputeCommitments()
+	if err != nil {
+		t.Fatalf("failed to compute commitments: %v", err)
+	}
+	if len(commitments) != 2 || commitments[0] != emptyBlobCommit {
+		t.Fatalf("commitments mismatch: have %x", commitments)
+	}
+	if err := sidecar.FillCommitmentsAndProofs(); err != nil {
+		t.Fatalf("failed to fill v0 sidecar: %v", err)
+	}
+	if len(sidecar.Proofs) != 2 || sidecar.Proofs[0] != emptyBlobProof {
+		t.Fatalf("v0 proofs mismatch: have %d", len(sidecar.Proofs))
+	}
+	for i := range blobs {
+		if err := kzg4844.VerifyBlobProof(&sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]); err != nil {
+			t.Errorf("blob %d: invalid v0 proof: %v", i, err)
+		}
+	}
+	sidecar = NewBlobTxSidecar(BlobSidecarVersion1, blobs, nil, nil)
+	if err := sidecar.FillCommitmentsAndProofs(); err != nil {
+		t.Fatalf("failed to fill v1 sidecar: %v", err)
+	}
+	if err := kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs); err != nil {
+		t.Errorf("invalid v1 proofs: %v", err)
+	}
+	sidecar = NewBlobTxSidecar(2, blobs, nil, nil)
+	if err := sidecar.FillCommitmentsAndProofs(); err == nil {
+		t.Errorf("unknown sidecar version filled")
+	}
+}
+
+// This test verifies that sidecar verification checks the shape, commitments and
+// proofs of the sidecar.
+func TestBlobTxSidecarVerify(t *testing.T) {
+	blob, _ := kzg4844.NewRandomBlob()
+	blobs := []kzg4844.Blob{*emptyBlob, blob}
+
+	for _, version := range []byte{BlobSidecarVersion0, BlobSidecarVersion1} {
+		sidecar := NewBlobTxSidecar(version, blobs, nil, nil)
+		if err := sidecar.FillCommitmentsAndProofs(); err != nil {
+			t.Fatalf("v%d: failed to fill sidecar: %v", version, err)
+		}
+		hashes := sidecar.BlobHashes()
+		if err := sidecar.Verify(hashes); err != nil {
+			t.Errorf("v%d: failed to verify valid sidecar: %v", version, err)
+		}
+		if err := sidecar.Verify(hashes[:1]); err == nil {
+			t.Errorf("v%d: sidecar verified with missing blob hash", version)
+		}
+		if err := sidecar.Verify([]common.Hash{hashes[1], hashes[0]}); err == nil {
+			t.Errorf("v%d: sidecar verified with reordered blob hashes", version)
+		}
+		mutated := sidecar.Copy()
+		mutated.Proofs = mutated.Proofs[1:]
+		if err := mutated.Verify(hashes); err == nil {
+			t.Errorf("v%d: sidecar verified with missing proof", version)
+		}
+		mutated = sidecar.Copy()
+		mutated.Proofs[0], mutated.Proofs[len(mutated.Proofs)-1] = mutated.Proofs[len(mutated.Proofs)-1], mutated.Proofs[0]
+		if err := mutated.Verify(hashes); err == nil {
+			t.Errorf("v%d: sidecar verified with invalid proofs", version)
+		}
+	}
+	sidecar := NewBlobTxSidecar(2, blobs, nil, nil)
+	sidecar.Commitments, _ = sidecar.ComputeCommitments()
+	if err := sidecar.Verify(sidecar.BlobHashes()); err == nil {
+		t.Errorf("unknown sidecar version verified")
+	}
+}
+
+// This test verifies that blob transactions violating structural invariants are
+// rejected before signing.
+func TestBlobTxValidate(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := NewCancunSigner(big.NewInt(1))
+
+	tests := []struct {
+		name   string
+		mutate func(tx *BlobTx)
+		fail   bool
+	}{
+		{"valid", func(tx *BlobTx) {}, false},
+		{"no sidecar", func(tx *BlobTx) { tx.Sidecar = nil }, false},
+		{"no chain id", func(tx *BlobTx) { tx.ChainID = nil }, false},
+		{"nil tip cap", func(tx *BlobTx) { tx.GasTipCap = nil }, true},
+		{"nil fee cap", func(tx *BlobTx) { tx.GasFeeCap = nil }, true},
+		{"nil blob fee cap", func(tx *BlobTx) { tx.BlobFeeCap = nil }, true},
+		{"no blob hashes", func(tx *BlobTx) { tx.BlobHashes = nil; tx.Sidecar = nil }, true},
+		{"bad hash version", func(tx *BlobTx) { tx.BlobHashes[0][0] = 0x02; tx.Sidecar = nil }, true},
+		{"sidecar count mismatch", func(tx *BlobTx) { tx.BlobHashes = append(tx.BlobHashes, tx.BlobHashes[0]) }, true},
+		{"sidecar hash mismatch", func(tx *BlobTx) { tx.BlobHashes[0][1] ^= 0xff }, true},
+	}
+	for _, tt := range tests {
+		blobtx := createEmptyBlobTxInner(true)
+		tt.mutate(blobtx)
+
+		err := blobtx.Validate()
+		if tt.fail != (err != nil) {
+			t.Errorf("%s: validation mismatch: have %v, want failure %v", tt.name, err, tt.fail)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidBlobTx) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, ErrInvalidBlobTx)
+		}
+		if _, serr := SignNewTx(key, signer, blobtx); (serr == nil) != (err == nil) || (err != nil && serr.Error() != err.Error()) {
+			t.Errorf("%s: signing error mismatch: have %v, want %v", tt.name, serr, err)
+		}
+	}
+}
+
+// This test verifies the detection of non-canonical field elements in blobs.
+func TestBlobTxSidecarHasValidFieldElements(t *testing.T) {
+	sidecar := NewBlobTxSidecar(BlobSidecarVersion0, make([]kzg4844.Blob, 3), nil, nil)
+	if ok, blob, elem := sidecar.HasValidFieldElements(); !ok || blob != -1 || elem != -1 {
+		t.Fatalf("valid sidecar mismatch: have (%v, %d, %d)", ok, blob, elem)
+	}
+	copy(sidecar.Blobs[1][5*32:], bytes.Repeat([]byte{0xff}, 32))
+	copy(sidecar.Blobs[2][0:], bytes.Repeat([]byte{0xff}, 32))
+	if ok, blob, elem := sidecar.HasValidFieldElements(); ok || blob != 1 || elem != 5 {
+		t.Fatalf("invalid sidecar mismatch: have (%v, %d, %d), want (false, 1, 5)", ok, blob, elem)
+	}
+}
+
+// This test verifies the blob presence predicates of transactions.
+func TestTransactionBlobPredicates(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tests := []struct {
+		name     string
+		tx       *Transaction
+		blobs    bool
+		sidecars bool
+	}{
+		{"legacy", NewTx(&LegacyTx{}), false, false},
+		{"blob with sidecar", createEmptyBlobTx(key, true), true, false},
+		{"blob without sidecar", createEmptyBlobTx(key, false), true, true},
+		{"stripped blob", createEmptyBlobTx(key, true).WithoutBlobTxSidecar(), true, true},
+		{"blobless blob", NewTx(&BlobTx{}), false, false},
+	}
+	for _, tt := range tests {
+		if have := tt.tx.HasBlobs(); have != tt.blobs {
+			t.Errorf("%s: HasBlobs mismatch: have %v, want %v", tt.name, have, tt.blobs)
+		}
+		if have := tt.tx.RequiresSidecar(); have != tt.sidecars {
+			t.Errorf("%s: RequiresSidecar mismatch: have %v, want %v", tt.name, have, tt.sidecars)
+		}
+	}
+}
+
+// This test verifies the combined execution and blob gas upper bound.
+func TestTransactionGasUsedUpperBound(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tests := []struct {
+		name string
+		tx   *Transaction
+		want uint64
+	}{
+		{"legacy", NewTx(&LegacyTx{Gas: 21000}), 21000},
+		{"blob", createEmptyBlobTx(key, false), 25000 + params.BlobTxBlobGasPerBlob},
+		{"blobless blob", NewTx(&BlobTx{Gas: 21000}), 21000},
+		{"saturated", NewTx(&BlobTx{Gas: math.MaxUint64, BlobHashes: []common.Hash{{}}}), math.MaxUint64},
+	}
+	for _, tt := range tests {
+		if have := tt.tx.GasUsedUpperBound(); have != tt.want {
+			t.Errorf("%s: gas upper bound mismatch: have %d, want %d", tt.name, have, tt.want)
+		}
+	}
+}
+
+// This test verifies that the priority weight of transactions is their total tip
+// in wei, with blob transactions also accounting for the blob fee.
+func TestTransactionWeight(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		blobtx  = createEmptyBlobTx(key, false) // tip 22, fee cap 5, gas 25000, blob fee cap 15
+		dynamic = NewTx(&DynamicFeeTx{GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(10), Gas: 21000})
+	)
+	tests := []struct {
+		name        string
+		tx          *Transaction
+		baseFee     *big.Int
+		blobBaseFee *big.Int
+		want        uint64
+	}{
+		{"dynamic", dynamic, big.NewInt(5), big.NewInt(1), 2 * 21000},
+		{"dynamic capped", dynamic, big.NewInt(9), nil, 1 * 21000},
+		{"dynamic underpriced", dynamic, big.NewInt(11), nil, 0},
+		{"blob", blobtx, big.NewInt(2), big.NewInt(10), 3*25000 + 10*params.BlobTxBlobGasPerBlob},
+		{"blob fee cap", blobtx, big.NewInt(2), nil, 3*25000 + 15*params.BlobTxBlobGasPerBlob},
+		{"blob underpriced", blobtx, big.NewInt(6), big.NewInt(10), 0},
+		{"blob fee underpriced", blobtx, big.NewInt(2), big.NewInt(16), 0},
+	}
+	for _, tt := range tests {
+		if have := tt.tx.Weight(tt.baseFee, tt.blobBaseFee); have.Cmp(new(big.Int).SetUint64(tt.want)) != 0 {
+			t.Errorf("%s: weight mismatch: have %v, want %d", tt.name, have, tt.want)
+		}
+	}
+}
+
+// This test verifies that the total cost of blob transactions is computed from
+// the effective gas price and the blob base fee.
+func TestTransactionTotalCost(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		blobtx = createEmptyBlobTx(key, false) // tip 22, fee cap 5, gas 25000, blob fee cap 15, value 99
+		legacy = NewTx(&LegacyTx{GasPrice: big.NewInt(3), Gas: 21000, Value: big.NewInt(7)})
+	)
+	tests := []struct {
+		name        string
+		tx          *Transaction
+		baseFee     *big.Int
+		blobBaseFee *big.Int
+		want        uint64
+	}{
+		{"legacy", legacy, big.NewInt(1), big.NewInt(1), 3*21000 + 7},
+		{"blob", blobtx, big.NewInt(2), big.NewInt(10), 5*25000 + 10*params.BlobTxBlobGasPerBlob + 99},
+		{"blob fee caps", blobtx, nil, nil, 5*25000 + 15*params.BlobTxBlobGasPerBlob + 99},
+	}
+	for _, tt := range tests {
+		if have := tt.tx.TotalCost(tt.baseFee, tt.blobBaseFee); have.Cmp(new(big.Int).SetUint64(tt.want)) != 0 {
+			t.Errorf("%s: total cost mismatch: have %v, want %d", tt.name, have, tt.want)
+		}
+	}
+	if have, want := blobtx.TotalCost(nil, nil), blobtx.Cost(); have.Cmp(want) != 0 {
+		t.Errorf("fee cap total cost mismatch: have %v, want %v", have, want)
+	}
+}
+
+// This test verifies the stateless field checks of blob transactions.
+func TestBlobTxSanityCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(tx *BlobTx)
+		fail   bool
+	}{
+		{"valid", func(tx *BlobTx) {}, false},
+		{"valid without sidecar", func(tx *BlobTx) { tx.Sidecar = nil }, false},
+		{"equal tip and fee cap", func(tx *BlobTx) { tx.GasTipCap = uint256.NewInt(30) }, false},
+		{"missing chain id", func(tx *BlobTx) { tx.ChainID = nil }, true},
+		{"missing fee cap", func(tx *BlobTx) { tx.GasFeeCap = nil }, true},
+		{"tip above fee cap", func(tx *BlobTx) { tx.GasTipCap = uint256.NewInt(31) }, true},
+		{"missing blob fee cap", func(tx *BlobTx) { tx.BlobFeeCap = nil }, true},
+		{"zero blob fee cap", func(tx *BlobTx) { tx.BlobFeeCap = new(uint256.Int) }, true},
+		{"no blob hashes", func(tx *BlobTx) { tx.BlobHashes = nil; tx.Sidecar = nil }, true},
+		{"wrong blob hash", func(tx *BlobTx) { tx.BlobHashes = []common.Hash{{0x01}} }, true},
+		{"extra blob hash", func(tx *BlobTx) { tx.BlobHashes = append(tx.BlobHashes, tx.BlobHashes[0]) }, true},
+		{"missing proof", func(tx *BlobTx) { tx.Sidecar.Proofs = nil }, true},
+		{"v1 proof count", func(tx *BlobTx) { tx.Sidecar.Version = BlobSidecarVersion1 }, true},
+		{"unknown version", func(tx *BlobTx) { tx.Sidecar.Version = 2 }, true},
+	}
+	for _, tt := range tests {
+		tx := createEmptyBlobTxInner(true)
+		tx.GasFeeCap = uint256.NewInt(30)
+		tt.modify(tx)
+
+		if err := tx.SanityCheck(); (err != nil) != tt.fail {
+			t.Errorf("%s: sanity check mismatch: have %v, want failure %v", tt.name, err, tt.fail)
+		}
+	}
+}
+
+// This test verifies that TransactionEquals only considers the consensus hash,
+// whereas TransactionDeepEquals also takes the sidecar into account.
+func TestTransactionEquals(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	withBlobs := createEmptyBlobTx(key, true)
+	withBlobsStripped := withBlobs.WithoutBlobTxSidecar()
+
+	if !TransactionEquals(withBlobs, withBlobsStripped) {
+		t.Error("stripped sidecar changed consensus equality")
+	}
+	if TransactionDeepEquals(withBlobs, withBlobsStripped) {
+		t.Error("stripped sidecar not detected by deep equality")
+	}
+	if !TransactionDeepEquals(withBlobs, withBlobs.WithBlobTxSidecar(withBlobs.BlobTxSidecar().Copy())) {
+		t.Error("copied sidecar not deep equal")
+	}
+	modified := withBlobs.BlobTxSidecar().Copy()
+	modified.Proofs[0][0] ^= 0xff
+	if TransactionDeepEquals(withBlobs, withBlobs.WithBlobTxSidecar(modified)) {
+		t.Error("modified sidecar proof not detected by deep equality")
+	}
+	other, _ := crypto.GenerateKey()
+	if TransactionEquals(withBlobs, createEmptyBlobTx(other, true)) {
+		t.Error("transactions from different senders are equal")
+	}
+	if TransactionEquals(withBlobs, nil) || !TransactionEquals(nil, nil) {
+		t.Error("nil transaction handling wrong")
+	}
+}
+
+// This test verifies that cloning a transaction deep copies its sidecar, so the
+// clone may be modified without affecting the original.
+func TestTransactionClone(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx := createEmptyBlobTx(key, true)
+	size := tx.Size()
+
+	clone := tx.Clone()
+	if !TransactionDeepEquals(tx, clone) {
+		t.Fatal("clone not deep equal to the original")
+	}
+	clone.BlobTxSidecar().Blobs[0][0] = 0x01
+	clone.BlobTxSidecar().Proofs = append(clone.BlobTxSidecar().Proofs, emptyBlobProof)
+
+	if tx.BlobTxSidecar().Blobs[0][0] != 0 || len(tx.BlobTxSidecar().Proofs) != 1 {
+		t.Error("modifying the clone's sidecar changed the original")
+	}
+	if tx.Size() != size {
+		t.Errorf("original size changed: have %d, want %d", tx.Size(), size)
+	}
+	if clone.Size() == size {
+		t.Error("clone size not recomputed after sidecar change")
+	}
+	// Transactions without sidecars should be cloneable too
+	legacy := NewTransaction(1, common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), []byte{0x01})
+	if cpy := legacy.Clone(); cpy.Hash() != legacy.Hash() {
+		t.Error("legacy clone hash mismatch")
+	}
+}
+
 var (
 	emptyBlob          = new(kzg4844.Blob)
 	emptyBlobCommit, _ = kzg4844.BlobToCommitment(emptyBlob)
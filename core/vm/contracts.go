@@ -1361,8 +1361,8 @@ func (b *kzgPointEvaluation) RequiredGas(input []byte) uint64 {
 }
 
 const (
-	blobVerifyInputLength           = 192  // Max input length for the point evaluation precompile.
-	blobCommitmentVersionKZG  uint8 = 0x01 // Version byte for the point evaluation precompile.
+	blobVerifyInputLength           = 192                        // Max input length for the point evaluation precompile.
+	blobCommitmentVersionKZG  uint8 = kzg4844.BlobHashVersionKZG // Version byte for the point evaluation precompile.
 	blobPrecompileReturnValue       = "000000000000000000000000000000000000000000000000000000000000100073eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"
 )
 
@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg4844
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// proofVerificationSamples is the number of blob proof verifications timed to
+// estimate the verification speed of the host.
+const proofVerificationSamples = 10
+
+var (
+	proofVerificationOnce sync.Once
+	proofVerificationTime time.Duration
+)
+
+// EstimatedProofVerificationTime returns the time a single blob proof verification
+// is expected to take on this machine, allowing callers to derive rate limits and
+// timeouts without hard coding the speed of some reference hardware.
+//
+// The estimate is the median of a few verifications timed on the first call, so
+// that first call also pays for loading the trusted setup. Later calls return the
+// cached value, even if the KZG backend is switched in between.
+func EstimatedProofVerificationTime() time.Duration {
+	proofVerificationOnce.Do(func() {
+		proofVerificationTime = measureProofVerificationTime(proofVerificationSamples)
+	})
+	return proofVerificationTime
+}
+
+// measureProofVerificationTime times the given number of blob proof verifications
+// with the active KZG backend and returns the median duration, or zero if the
+// proof to verify could not be created.
+func measureProofVerificationTime(samples int) time.Duration {
+	var blob Blob
+	commitment, err := BlobToCommitment(&blob)
+	if err != nil {
+		return 0
+	}
+	proof, err := ComputeBlobProof(&blob, commitment)
+	if err != nil {
+		return 0
+	}
+	times := make([]time.Duration, samples)
+	for i := range times {
+		start := time.Now()
+		VerifyBlobProof(&blob, commitment, proof)
+		times[i] = time.Since(start)
+	}
+	slices.Sort(times)
+	return times[len(times)/2]
+}
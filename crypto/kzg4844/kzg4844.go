@@ -18,12 +18,18 @@
 package kzg4844
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
 	"embed"
 	"errors"
+	"fmt"
 	"hash"
 	"reflect"
 	"sync/atomic"
 
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -38,8 +44,19 @@ var (
 
 const CellProofsPerBlob = 128
 
+// Byte sizes of the serialized KZG primitives.
+const (
+	BlobSize       = 131072 // Size of a single blob
+	CommitmentSize = 48     // Size of a compressed G1 commitment
+	ProofSize      = 48     // Size of a compressed G1 proof
+)
+
+// BlobHashVersionKZG is the version byte of blob hashes derived from KZG
+// commitments, prefixing the versioned hash in place of the first hash byte.
+const BlobHashVersionKZG byte = 0x01
+
 // Blob represents a 4844 data blob.
-type Blob [131072]byte
+type Blob [BlobSize]byte
 
 // UnmarshalJSON parses a blob in hex syntax.
 func (b *Blob) UnmarshalJSON(input []byte) error {
@@ -51,8 +68,28 @@ func (b *Blob) MarshalText() ([]byte, error) {
 	return hexutil.Bytes(b[:]).MarshalText()
 }
 
+// NewRandomBlob creates a blob filled with random field elements, each reduced
+// modulo the BLS12-381 scalar field order so the result is canonical. It is
+// meant to be used in tests that need structurally non-trivial blob content.
+func NewRandomBlob() (Blob, error) {
+	var (
+		blob Blob
+		buf  [32]byte
+		elem fr.Element
+	)
+	for i := 0; i < len(blob); i += len(buf) {
+		if _, err := rand.Read(buf[:]); err != nil {
+			return Blob{}, err
+		}
+		elem.SetBytes(buf[:])
+		canonical := elem.Bytes()
+		copy(blob[i:], canonical[:])
+	}
+	return blob, nil
+}
+
 // Commitment is a serialized commitment to a polynomial.
-type Commitment [48]byte
+type Commitment [CommitmentSize]byte
 
 // UnmarshalJSON parses a commitment in hex syntax.
 func (c *Commitment) UnmarshalJSON(input []byte) error {
@@ -64,8 +101,28 @@ func (c Commitment) MarshalText() ([]byte, error) {
 	return hexutil.Bytes(c[:]).MarshalText()
 }
 
+// CommitmentFromBytes converts a byte slice into a commitment, returning an error
+// if its length is not exactly that of a commitment.
+func CommitmentFromBytes(b []byte) (Commitment, error) {
+	var c Commitment
+	if len(b) != len(c) {
+		return Commitment{}, fmt.Errorf("invalid commitment length %d, want %d", len(b), len(c))
+	}
+	copy(c[:], b)
+	return c, nil
+}
+
+// IsOnCurve reports whether the commitment is a valid compressed BLS12-381 G1
+// point, i.e. it is on the curve and in the correct subgroup. It is a cheap way
+// to weed out malformed commitments before the expensive KZG operations.
+func (c Commitment) IsOnCurve() bool {
+	var p bls12381.G1Affine
+	_, err := p.SetBytes(c[:])
+	return err == nil
+}
+
 // Proof is a serialized commitment to the quotient polynomial.
-type Proof [48]byte
+type Proof [ProofSize]byte
 
 // UnmarshalJSON parses a proof in hex syntax.
 func (p *Proof) UnmarshalJSON(input []byte) error {
@@ -77,12 +134,27 @@ func (p Proof) MarshalText() ([]byte, error) {
 	return hexutil.Bytes(p[:]).MarshalText()
 }
 
+// ProofFromBytes converts a byte slice into a proof, returning an error if its
+// length is not exactly that of a proof.
+func ProofFromBytes(b []byte) (Proof, error) {
+	var p Proof
+	if len(b) != len(p) {
+		return Proof{}, fmt.Errorf("invalid proof length %d, want %d", len(b), len(p))
+	}
+	copy(p[:], b)
+	return p, nil
+}
+
 // Point is a BLS field element.
 type Point [32]byte
 
 // Claim is a claimed evaluation value in a specific point.
 type Claim [32]byte
 
+// Scalar is a BLS field element, used both as the evaluation point and as the
+// evaluated value of single point proofs (as in the point evaluation precompile).
+type Scalar [32]byte
+
 // useCKZG controls whether the cryptography should use the Go or C backend.
 var useCKZG atomic.Bool
 
@@ -106,8 +178,19 @@ func UseCKZG(use bool) error {
 	return nil
 }
 
+// Verifier is the subset of the KZG operations used for blob proof checks, which
+// may be substituted in test builds (kzgmock tag) by a fast fake implementation.
+type Verifier interface {
+	BlobToCommitment(blob *Blob) (Commitment, error)
+	ComputeBlobProof(blob *Blob, commitment Commitment) (Proof, error)
+	VerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error
+}
+
 // BlobToCommitment creates a small commitment out of a data blob.
 func BlobToCommitment(blob *Blob) (Commitment, error) {
+	if v := customVerifier(); v != nil {
+		return v.BlobToCommitment(blob)
+	}
 	if useCKZG.Load() {
 		return ckzgBlobToCommitment(blob)
 	}
@@ -132,11 +215,34 @@ func VerifyProof(commitment Commitment, point Point, claim Claim, proof Proof) e
 	return gokzgVerifyProof(commitment, point, claim, proof)
 }
 
+// ComputeKZGProof computes the KZG proof at the evaluation point z for the
+// polynomial represented by the blob, returning it along with the value y = p(z).
+//
+// This is the scalar based flavour of ComputeProof.
+func ComputeKZGProof(blob *Blob, z Scalar) (Proof, Scalar, error) {
+	proof, claim, err := ComputeProof(blob, Point(z))
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	return proof, Scalar(claim), nil
+}
+
+// VerifyKZGProof verifies the KZG proof that the polynomial committed to
+// evaluates to y at point z.
+//
+// This is the scalar based flavour of VerifyProof.
+func VerifyKZGProof(commitment Commitment, z, y Scalar, proof Proof) error {
+	return VerifyProof(commitment, Point(z), Claim(y), proof)
+}
+
 // ComputeBlobProof returns the KZG proof that is used to verify the blob against
 // the commitment.
 //
 // This method does not verify that the commitment is correct with respect to blob.
 func ComputeBlobProof(blob *Blob, commitment Commitment) (Proof, error) {
+	if v := customVerifier(); v != nil {
+		return v.ComputeBlobProof(blob, commitment)
+	}
 	if useCKZG.Load() {
 		return ckzgComputeBlobProof(blob, commitment)
 	}
@@ -145,6 +251,9 @@ func ComputeBlobProof(blob *Blob, commitment Commitment) (Proof, error) {
 
 // VerifyBlobProof verifies that the blob data corresponds to the provided commitment.
 func VerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error {
+	if v := customVerifier(); v != nil {
+		return v.VerifyBlobProof(blob, commitment, proof)
+	}
 	if useCKZG.Load() {
 		return ckzgVerifyBlobProof(blob, commitment, proof)
 	}
@@ -172,6 +281,19 @@ func ComputeCellProofs(blob *Blob) ([]Proof, error) {
 	return gokzgComputeCellProofs(blob)
 }
 
+// FirstInvalidFieldElement returns the index of the first field element in the
+// blob which is not canonical (i.e. not below the BLS12-381 scalar modulus), or
+// -1 if all of them are valid.
+func FirstInvalidFieldElement(blob *Blob) int {
+	var elem fr.Element
+	for i := 0; i < len(blob); i += fr.Bytes {
+		if err := elem.SetBytesCanonical(blob[i : i+fr.Bytes]); err != nil {
+			return i / fr.Bytes
+		}
+	}
+	return -1
+}
+
 // CalcBlobHashV1 calculates the 'versioned blob hash' of a commitment.
 // The given hasher must be a sha256 hash instance, otherwise the result will be invalid!
 func CalcBlobHashV1(hasher hash.Hash, commit *Commitment) (vh [32]byte) {
@@ -181,11 +303,35 @@ func CalcBlobHashV1(hasher hash.Hash, commit *Commitment) (vh [32]byte) {
 	hasher.Reset()
 	hasher.Write(commit[:])
 	hasher.Sum(vh[:0])
-	vh[0] = 0x01 // version
+	vh[0] = BlobHashVersionKZG
 	return vh
 }
 
 // IsValidVersionedHash checks that h is a structurally-valid versioned blob hash.
 func IsValidVersionedHash(h []byte) bool {
-	return len(h) == 32 && h[0] == 0x01
+	return len(h) == 32 && h[0] == BlobHashVersionKZG
+}
+
+// ValidateBlobHashVersion checks that the version byte of a blob hash is the
+// KZG one, returning an error otherwise.
+func ValidateBlobHashVersion(h common.Hash) error {
+	if h[0] != BlobHashVersionKZG {
+		return fmt.Errorf("invalid blob hash version %#x, want %#x", h[0], BlobHashVersionKZG)
+	}
+	return nil
+}
+
+// CommitmentsEqual reports whether two commitments are identical. Since the
+// versioned hash is a deterministic function of the commitment, this is
+// equivalent to comparing their versioned hashes, without computing them.
+func CommitmentsEqual(a, b Commitment) bool {
+	return a == b
+}
+
+// CommitmentVersionedHashEqual reports whether the commitment's V1 versioned
+// hash equals h. Unlike CalcBlobHashV1, it does not need a hasher instance.
+func CommitmentVersionedHashEqual(commit Commitment, h common.Hash) bool {
+	vh := sha256.Sum256(commit[:])
+	vh[0] = BlobHashVersionKZG
+	return vh == h
 }
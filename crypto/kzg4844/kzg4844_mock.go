@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build kzgmock
+
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"errors"
+	"sync/atomic"
+)
+
+// verifier is the KZG implementation substituted via SetVerifier, if any.
+var verifier atomic.Pointer[Verifier]
+
+// SetVerifier substitutes the KZG implementation used for computing commitments
+// and blob proofs, and verifying the latter. Passing nil restores the default
+// implementation. It is only available in test builds with the kzgmock tag.
+func SetVerifier(v Verifier) {
+	if v == nil {
+		verifier.Store(nil)
+		return
+	}
+	verifier.Store(&v)
+}
+
+// customVerifier returns the substituted KZG verifier, nil if none is set.
+func customVerifier() Verifier {
+	if v := verifier.Load(); v != nil {
+		return *v
+	}
+	return nil
+}
+
+// MockVerifier is a fake KZG implementation deriving commitments and proofs from
+// hashes of the blob data instead of doing the real curve arithmetic. It checks
+// the consistency of blobs, commitments and proofs produced by itself, but has
+// no cryptographic meaning.
+type MockVerifier struct{}
+
+// BlobToCommitment derives a fake commitment from the hash of the blob.
+func (MockVerifier) BlobToCommitment(blob *Blob) (Commitment, error) {
+	var commitment Commitment
+	hash := sha256.Sum256(blob[:])
+	copy(commitment[:], hash[:])
+	return commitment, nil
+}
+
+// ComputeBlobProof derives a fake proof from the hash of the blob and commitment.
+func (MockVerifier) ComputeBlobProof(blob *Blob, commitment Commitment) (Proof, error) {
+	var proof Proof
+	hasher := sha256.New()
+	hasher.Write(blob[:])
+	hasher.Write(commitment[:])
+	hasher.Sum(proof[:0])
+	return proof, nil
+}
+
+// VerifyBlobProof checks that the commitment and proof are the ones derived from
+// the blob by the mock.
+func (m MockVerifier) VerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) error {
+	if want, _ := m.BlobToCommitment(blob); commitment != want {
+		return errors.New("mock commitment mismatch")
+	}
+	if want, _ := m.ComputeBlobProof(blob, commitment); proof != want {
+		return errors.New("mock proof mismatch")
+	}
+	return nil
+}
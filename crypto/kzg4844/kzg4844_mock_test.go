@@ -0,0 +1,68 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build kzgmock
+
+package kzg4844
+
+import "testing"
+
+// Tests that the mock verifier substitutes the blob proof operations, accepting
+// its own commitments and proofs and rejecting mismatching ones.
+func TestMockVerifier(t *testing.T) {
+	SetVerifier(MockVerifier{})
+	defer SetVerifier(nil)
+
+	blob, err := NewRandomBlob()
+	if err != nil {
+		t.Fatalf("failed to create blob: %v", err)
+	}
+	commitment, err := BlobToCommitment(&blob)
+	if err != nil {
+		t.Fatalf("failed to create commitment: %v", err)
+	}
+	proof, err := ComputeBlobProof(&blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to create proof: %v", err)
+	}
+	if err := VerifyBlobProof(&blob, commitment, proof); err != nil {
+		t.Fatalf("failed to verify mock proof: %v", err)
+	}
+	// Real KZG would reject the fake commitment, make sure the mock was used
+	if commitment.IsOnCurve() {
+		t.Fatalf("commitment computed by the real implementation")
+	}
+	other := blob
+	other[0] ^= 0x01
+	if err := VerifyBlobProof(&other, commitment, proof); err == nil {
+		t.Errorf("mutated blob verified")
+	}
+	badCommitment := commitment
+	badCommitment[0] ^= 0x01
+	if err := VerifyBlobProof(&blob, badCommitment, proof); err == nil {
+		t.Errorf("mutated commitment verified")
+	}
+	badProof := proof
+	badProof[0] ^= 0x01
+	if err := VerifyBlobProof(&blob, commitment, badProof); err == nil {
+		t.Errorf("mutated proof verified")
+	}
+	// Restoring the default implementation should reject the mock proofs
+	SetVerifier(nil)
+	if err := VerifyBlobProof(&blob, commitment, proof); err == nil {
+		t.Errorf("mock proof verified by the real implementation")
+	}
+}
@@ -18,10 +18,13 @@ package kzg4844
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"testing"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	gokzg4844 "github.com/crate-crypto/go-eth-kzg"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 func randFieldElement() [32]byte {
@@ -37,14 +40,163 @@ func randFieldElement() [32]byte {
 }
 
 func randBlob() *Blob {
-	var blob Blob
-	for i := 0; i < len(blob); i += gokzg4844.SerializedScalarSize {
-		fieldElementBytes := randFieldElement()
-		copy(blob[i:i+gokzg4844.SerializedScalarSize], fieldElementBytes[:])
+	blob, err := NewRandomBlob()
+	if err != nil {
+		panic("failed to get random blob")
 	}
 	return &blob
 }
 
+// Tests that random blobs consist of canonical field elements only.
+func TestNewRandomBlob(t *testing.T) {
+	blob, err := NewRandomBlob()
+	if err != nil {
+		t.Fatalf("failed to create random blob: %v", err)
+	}
+	for i := 0; i < len(blob); i += gokzg4844.SerializedScalarSize {
+		var elem fr.Element
+		if err := elem.SetBytesCanonical(blob[i : i+gokzg4844.SerializedScalarSize]); err != nil {
+			t.Fatalf("field element %d not canonical: %v", i/gokzg4844.SerializedScalarSize, err)
+		}
+	}
+	if _, err := BlobToCommitment(&blob); err != nil {
+		t.Fatalf("failed to commit to random blob: %v", err)
+	}
+}
+
+// Tests the commitment and versioned hash comparisons against the hasher based
+// versioned hash calculation.
+func TestCommitmentEquality(t *testing.T) {
+	var a, b Commitment
+	rand.Read(a[:])
+	rand.Read(b[:])
+
+	if !CommitmentsEqual(a, a) {
+		t.Error("identical commitments reported different")
+	}
+	if CommitmentsEqual(a, b) {
+		t.Error("different commitments reported equal")
+	}
+	hasher := sha256.New()
+	if !CommitmentVersionedHashEqual(a, CalcBlobHashV1(hasher, &a)) {
+		t.Error("commitment mismatches its own versioned hash")
+	}
+	if CommitmentVersionedHashEqual(a, CalcBlobHashV1(hasher, &b)) {
+		t.Error("commitment matches another versioned hash")
+	}
+	unversioned := common.Hash(sha256.Sum256(a[:]))
+	if unversioned[0] != 0x01 && CommitmentVersionedHashEqual(a, unversioned) {
+		t.Error("commitment matches its unversioned hash")
+	}
+	if n := testing.AllocsPerRun(100, func() { CommitmentVersionedHashEqual(a, common.Hash{}) }); n != 0 {
+		t.Errorf("versioned hash comparison allocated %v times", n)
+	}
+}
+
+// Tests that commitments and proofs round trip through their byte form, and that
+// inputs of the wrong length are rejected.
+func TestCommitmentAndProofFromBytes(t *testing.T) {
+	blob := randBlob()
+	commitment, err := BlobToCommitment(blob)
+	if err != nil {
+		t.Fatalf("failed to create commitment: %v", err)
+	}
+	proof, err := ComputeBlobProof(blob, commitment)
+	if err != nil {
+		t.Fatalf("failed to create proof: %v", err)
+	}
+	if have, err := CommitmentFromBytes(commitment[:]); err != nil || have != commitment {
+		t.Errorf("commitment round trip mismatch: have %x, %v, want %x", have, err, commitment)
+	}
+	if have, err := ProofFromBytes(proof[:]); err != nil || have != proof {
+		t.Errorf("proof round trip mismatch: have %x, %v, want %x", have, err, proof)
+	}
+	for _, size := range []int{0, 47, 49} {
+		if _, err := CommitmentFromBytes(make([]byte, size)); err == nil {
+			t.Errorf("commitment of length %d accepted", size)
+		}
+		if _, err := ProofFromBytes(make([]byte, size)); err == nil {
+			t.Errorf("proof of length %d accepted", size)
+		}
+	}
+}
+
+// Tests that blob hashes are only accepted with the KZG version byte.
+func TestValidateBlobHashVersion(t *testing.T) {
+	var commitment Commitment
+	rand.Read(commitment[:])
+
+	hash := common.Hash(CalcBlobHashV1(sha256.New(), &commitment))
+	if err := ValidateBlobHashVersion(hash); err != nil {
+		t.Fatalf("valid blob hash rejected: %v", err)
+	}
+	for _, version := range []byte{0x00, 0x02, 0xff} {
+		hash[0] = version
+		if err := ValidateBlobHashVersion(hash); err == nil {
+			t.Errorf("blob hash with version %#x accepted", version)
+		}
+	}
+}
+
+// Tests that commitments are checked for being valid compressed G1 points.
+func TestCommitmentIsOnCurve(t *testing.T) {
+	commitment, err := BlobToCommitment(randBlob())
+	if err != nil {
+		t.Fatalf("failed to create commitment: %v", err)
+	}
+	infinity := Commitment{0xc0}
+
+	overflow := Commitment{0x80 | 0x1f}
+	for i := 1; i < len(overflow); i++ {
+		overflow[i] = 0xff
+	}
+	tests := []struct {
+		name       string
+		commitment Commitment
+		want       bool
+	}{
+		{"valid", commitment, true},
+		{"infinity", infinity, true},
+		{"uncompressed flag", Commitment{}, false},
+		{"x above modulus", overflow, false},
+	}
+	for _, tt := range tests {
+		if have := tt.commitment.IsOnCurve(); have != tt.want {
+			t.Errorf("%s: on curve mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+// Tests that non-canonical field elements are detected in blobs.
+func TestFirstInvalidFieldElement(t *testing.T) {
+	blob := randBlob()
+	if idx := FirstInvalidFieldElement(blob); idx != -1 {
+		t.Fatalf("canonical blob reported invalid at %d", idx)
+	}
+	for _, idx := range []int{4095, 17, 0} {
+		for i := 0; i < fr.Bytes; i++ {
+			blob[idx*fr.Bytes+i] = 0xff
+		}
+		if have := FirstInvalidFieldElement(blob); have != idx {
+			t.Errorf("invalid field element mismatch: have %d, want %d", have, idx)
+		}
+	}
+}
+
+// Tests that the proof verification time estimate is measured once and cached.
+func TestEstimatedProofVerificationTime(t *testing.T) {
+	estimate := EstimatedProofVerificationTime()
+	if estimate <= 0 {
+		t.Fatalf("non-positive verification time estimate: %v", estimate)
+	}
+	if estimate > time.Second {
+		t.Errorf("implausible verification time estimate: %v", estimate)
+	}
+	if cached := EstimatedProofVerificationTime(); cached != estimate {
+		t.Errorf("estimate not cached: have %v, want %v", cached, estimate)
+	}
+}
+
 func TestCKZGWithPoint(t *testing.T)  { testKZGWithPoint(t, true) }
 func TestGoKZGWithPoint(t *testing.T) { testKZGWithPoint(t, false) }
 func testKZGWithPoint(t *testing.T, ckzg bool) {
@@ -68,6 +220,20 @@ func testKZGWithPoint(t *testing.T, ckzg bool) {
 	if err := VerifyProof(commitment, point, claim, proof); err != nil {
 		t.Fatalf("failed to verify KZG proof at point: %v", err)
 	}
+	// Cross check the scalar flavour of the same API
+	sproof, y, err := ComputeKZGProof(blob, Scalar(point))
+	if err != nil {
+		t.Fatalf("failed to create scalar KZG proof: %v", err)
+	}
+	if sproof != proof || y != Scalar(claim) {
+		t.Fatalf("scalar KZG proof mismatch: have (%x, %x), want (%x, %x)", sproof, y, proof, claim)
+	}
+	if err := VerifyKZGProof(commitment, Scalar(point), y, sproof); err != nil {
+		t.Fatalf("failed to verify scalar KZG proof: %v", err)
+	}
+	if err := VerifyKZGProof(commitment, Scalar(point), Scalar(randFieldElement()), sproof); err == nil {
+		t.Fatal("scalar KZG proof verified with wrong evaluation")
+	}
 }
 
 func TestCKZGWithBlob(t *testing.T)  { testKZGWithBlob(t, true) }
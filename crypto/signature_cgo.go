@@ -33,8 +33,9 @@ func Ecrecover(hash, sig []byte) ([]byte, error) {
 	return secp256k1.RecoverPubkey(hash, sig)
 }
 
-// SigToPub returns the public key that created the given signature.
-func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+// EcrecoverPubkey returns the public key that created the given signature,
+// combining the recovery and the unmarshalling into a single step.
+func EcrecoverPubkey(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	s, err := Ecrecover(hash, sig)
 	if err != nil {
 		return nil, err
@@ -42,6 +43,11 @@ func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	return UnmarshalPubkey(s)
 }
 
+// SigToPub returns the public key that created the given signature.
+func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	return EcrecoverPubkey(hash, sig)
+}
+
 // Sign calculates an ECDSA signature.
 //
 // This function is susceptible to chosen plaintext attacks that can leak
@@ -55,8 +55,9 @@ func sigToPub(hash, sig []byte) (*secp256k1.PublicKey, error) {
 	return pub, err
 }
 
-// SigToPub returns the public key that created the given signature.
-func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+// EcrecoverPubkey returns the public key that created the given signature,
+// combining the recovery and the unmarshalling into a single step.
+func EcrecoverPubkey(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	pub, err := sigToPub(hash, sig)
 	if err != nil {
 		return nil, err
@@ -70,6 +71,11 @@ func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	}, nil
 }
 
+// SigToPub returns the public key that created the given signature.
+func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	return EcrecoverPubkey(hash, sig)
+}
+
 // Sign calculates an ECDSA signature.
 //
 // This function is susceptible to chosen plaintext attacks that can leak
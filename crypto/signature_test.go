@@ -44,6 +44,19 @@ func TestEcrecover(t *testing.T) {
 	}
 }
 
+func TestEcrecoverPubkey(t *testing.T) {
+	pubkey, err := EcrecoverPubkey(testmsg, testsig)
+	if err != nil {
+		t.Fatalf("recover error: %s", err)
+	}
+	if have := FromECDSAPub(pubkey); !bytes.Equal(have, testpubkey) {
+		t.Errorf("pubkey mismatch: want: %x have: %x", testpubkey, have)
+	}
+	if _, err := EcrecoverPubkey(testmsg, testsig[:len(testsig)-1]); err == nil {
+		t.Errorf("no error for incomplete signature")
+	}
+}
+
 func TestVerifySignature(t *testing.T) {
 	sig := testsig[:len(testsig)-1] // remove recovery id
 	if !VerifySignature(testpubkey, testmsg, sig) {
@@ -142,6 +155,31 @@ func BenchmarkEcrecoverSignature(b *testing.B) {
 	}
 }
 
+// BenchmarkEcrecoverToAddress compares deriving the signer address by hashing
+// the raw recovered public key bytes, as done by the transaction signers, with
+// unmarshalling the key first via EcrecoverPubkey.
+func BenchmarkEcrecoverToAddress(b *testing.B) {
+	b.Run("ecrecover+keccak", func(b *testing.B) {
+		for b.Loop() {
+			pub, err := Ecrecover(testmsg, testsig)
+			if err != nil {
+				b.Fatal("ecrecover error", err)
+			}
+			var addr common.Address
+			copy(addr[:], Keccak256(pub[1:])[12:])
+		}
+	})
+	b.Run("ecrecoverpubkey+address", func(b *testing.B) {
+		for b.Loop() {
+			pub, err := EcrecoverPubkey(testmsg, testsig)
+			if err != nil {
+				b.Fatal("ecrecover error", err)
+			}
+			PubkeyToAddress(*pub)
+		}
+	})
+}
+
 func BenchmarkVerifySignature(b *testing.B) {
 	sig := testsig[:len(testsig)-1] // remove recovery id
 	for b.Loop() {
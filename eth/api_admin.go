@@ -23,12 +23,17 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+// txFetcherHealthTimeout is the maximum time to wait for the transaction fetcher
+// to answer a health check before it is considered stuck.
+const txFetcherHealthTimeout = time.Second
+
 // AdminAPI is the collection of Ethereum full node related APIs for node
 // administration.
 type AdminAPI struct {
@@ -141,3 +146,9 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// TxFetcherHealthy reports whether the transaction fetcher event loop responds
+// to a ping in a timely manner, e.g. for liveness probes.
+func (api *AdminAPI) TxFetcherHealthy() bool {
+	return api.eth.handler.txFetcher.Healthy(txFetcherHealthTimeout)
+}
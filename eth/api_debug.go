@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -526,3 +527,64 @@ func (api *DebugAPI) ExecutionWitnessByHash(hash common.Hash) (*stateless.ExtWit
 	}
 	return result.Witness().ToExtWitness(), nil
 }
+
+// TransactionValidationResult is the outcome of debug_validateTransaction.
+type TransactionValidationResult struct {
+	Valid        bool           `json:"valid"`
+	ErrorCode    string         `json:"errorCode,omitempty"`
+	ErrorMessage string         `json:"errorMessage,omitempty"`
+	Explanation  string         `json:"explanation,omitempty"` // Human readable failure reason with remediation advice
+	Hash         *common.Hash   `json:"hash,omitempty"`
+	Type         hexutil.Uint64 `json:"type"`
+	SizeBytes    hexutil.Uint64 `json:"sizeBytes"`
+	Gas          hexutil.Uint64 `json:"gas"`
+	BlobCount    hexutil.Uint64 `json:"blobCount"`
+	KZGValid     *bool          `json:"kzgValid,omitempty"` // Only set for blob transactions with a sidecar
+}
+
+// ValidateTransaction decodes a raw transaction and runs the stateless checks
+// of the transaction pool against the current head, reporting why it would be
+// rejected. State dependent checks (nonce, balance) are not performed.
+func (api *DebugAPI) ValidateTransaction(input hexutil.Bytes) *TransactionValidationResult {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return &TransactionValidationResult{
+			ErrorCode:    "decode",
+			ErrorMessage: err.Error(),
+		}
+	}
+	hash := tx.Hash()
+	result := &TransactionValidationResult{
+		Valid:     true,
+		Hash:      &hash,
+		Type:      hexutil.Uint64(tx.Type()),
+		SizeBytes: hexutil.Uint64(tx.Size()),
+		Gas:       hexutil.Uint64(tx.Gas()),
+		BlobCount: hexutil.Uint64(len(tx.BlobHashes())),
+	}
+	err := api.eth.txPool.ValidateTxBasics(tx)
+	if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+		// Reuse the pool's verdict on the proofs if its validation got that far,
+		// only verifying them here if it bailed out earlier
+		var valid bool
+		switch {
+		case err == nil:
+			valid = true
+		case errors.Is(err, txpool.ErrInvalidBlobProof):
+			valid = false
+		default:
+			valid = sidecar.Verify(tx.BlobHashes()) == nil
+		}
+		result.KZGValid = &valid
+	}
+	if err != nil {
+		result.Valid = false
+		result.ErrorCode = "invalid"
+		if code := txpool.ValidationErrorCode(err); code != "" {
+			result.ErrorCode = code
+		}
+		result.ErrorMessage = err.Error()
+		result.Explanation = txpool.FormatValidationError(err)
+	}
+	return result
+}
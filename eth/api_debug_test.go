@@ -29,11 +29,13 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool/testutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
@@ -335,3 +337,58 @@ func TestGetModifiedAccounts(t *testing.T) {
 		}
 	})
 }
+
+// Tests that debug_validateTransaction reports the reasons of rejections.
+func TestValidateTransaction(t *testing.T) {
+	api := NewDebugAPI(initBackend(false).eth)
+
+	encode := func(tx *types.Transaction) hexutil.Bytes {
+		blob, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode transaction: %v", err)
+		}
+		return blob
+	}
+	makeInvalid := func(kind uint8) *types.Transaction {
+		maker, err := testutil.NewInvalidTxMaker(kind, gspec.Config)
+		if err != nil {
+			t.Fatalf("failed to create invalid tx maker: %v", err)
+		}
+		tx, err := maker(0)
+		if err != nil {
+			t.Fatalf("failed to create invalid transaction: %v", err)
+		}
+		return tx
+	}
+	lowGas, _ := types.SignTx(types.NewTransaction(0, common.Address{}, nil, params.TxGas-1, big.NewInt(params.GWei), nil), signer, key)
+
+	tests := []struct {
+		name  string
+		input hexutil.Bytes
+		valid bool
+		code  string
+	}{
+		{"garbage", hexutil.Bytes{0xde, 0xad}, false, "decode"},
+		{"valid", encode(makeTx(0, nil, nil, key)), true, ""},
+		{"intrinsic gas", encode(lowGas), false, "intrinsic_gas"},
+		{"bad signature", encode(makeInvalid(types.DynamicFeeTxType)), false, "invalid_sender"},
+	}
+	for _, tt := range tests {
+		result := api.ValidateTransaction(tt.input)
+		if result.Valid != tt.valid || result.ErrorCode != tt.code {
+			t.Errorf("%s: result mismatch: have valid %v, code %q (%s), want valid %v, code %q", tt.name, result.Valid, result.ErrorCode, result.ErrorMessage, tt.valid, tt.code)
+		}
+		if (result.Explanation != "") != (!tt.valid && tt.code != "decode") {
+			t.Errorf("%s: explanation mismatch: have %q", tt.name, result.Explanation)
+		}
+		if result.KZGValid != nil {
+			t.Errorf("%s: kzg validity reported for non-blob transaction", tt.name)
+		}
+	}
+	// Blob transactions should also report the validity of their proofs
+	blobtx := makeInvalid(types.BlobTxType)
+	result := api.ValidateTransaction(encode(blobtx))
+	if result.Valid || result.BlobCount != 1 || result.KZGValid == nil || *result.KZGValid {
+		t.Errorf("blob transaction result mismatch: %+v", result)
+	}
+}
@@ -303,6 +303,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
+	if config.BlobValidationWorkers > 0 {
+		config.BlobPool.KZGWorkers = config.BlobValidationWorkers
+	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
 
 	if config.BlobPool.Datadir != "" {
@@ -337,6 +340,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
+
+		BlobValidationWorkers: config.BlobValidationWorkers,
+		TxServeRate:           float64(config.MaxTxFetchResponseRate),
 	}); err != nil {
 		return nil, err
 	}
@@ -452,7 +458,7 @@ func (s *Ethereum) Start() error {
 	s.handler.Start(s.p2pServer.MaxPeers)
 
 	// Start the connection manager
-	s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() })
+	s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() }, s.handler.peerPenalty)
 
 	// start log indexer
 	s.filterMaps.Start()
@@ -52,13 +52,15 @@ var (
 // dropper monitors the state of the peer pool and makes changes as follows:
 //   - during sync the Downloader handles peer connections, so dropper is disabled
 //   - if not syncing and the peer count is close to the limit, it drops peers
-//     randomly every peerDropInterval to make space for new peers
+//     every peerDropInterval to make space for new peers, preferring the ones
+//     with the highest misbehaviour penalty and picking randomly otherwise
 //   - peers are dropped separately from the inboud pool and from the dialed pool
 type dropper struct {
 	maxDialPeers    int // maximum number of dialed peers
 	maxInboundPeers int // maximum number of inbound peers
 	peersFunc       getPeersFunc
 	syncingFunc     getSyncingFunc
+	penaltyFunc     getPenaltyFunc
 
 	// peerDropTimer introduces churn if we are close to limit capacity.
 	// We handle Dialed and Inbound connections separately
@@ -75,6 +77,9 @@ type getPeersFunc func() []*p2p.Peer
 // Returns true while syncing, false when synced.
 type getSyncingFunc func() bool
 
+// Callback type to get the misbehaviour penalty of a peer by its id.
+type getPenaltyFunc func(id string) int64
+
 func newDropper(maxDialPeers, maxInboundPeers int) *dropper {
 	cm := &dropper{
 		maxDialPeers:    maxDialPeers,
@@ -89,9 +94,10 @@ func newDropper(maxDialPeers, maxInboundPeers int) *dropper {
 }
 
 // Start the dropper.
-func (cm *dropper) Start(srv *p2p.Server, syncingFunc getSyncingFunc) {
+func (cm *dropper) Start(srv *p2p.Server, syncingFunc getSyncingFunc, penaltyFunc getPenaltyFunc) {
 	cm.peersFunc = srv.Peers
 	cm.syncingFunc = syncingFunc
+	cm.penaltyFunc = penaltyFunc
 	cm.wg.Add(1)
 	go cm.loop()
 }
@@ -103,7 +109,9 @@ func (cm *dropper) Stop() {
 	cm.wg.Wait()
 }
 
-// dropRandomPeer selects one of the peers randomly and drops it from the peer pool.
+// dropRandomPeer selects one of the peers and drops it from the peer pool. The
+// peer with the highest misbehaviour penalty is chosen, or a random one if none
+// of the candidates were penalized.
 func (cm *dropper) dropRandomPeer() bool {
 	peers := cm.peersFunc()
 	var numInbound int
@@ -127,6 +135,14 @@ func (cm *dropper) dropRandomPeer() bool {
 	droppable := slices.DeleteFunc(peers, selectDoNotDrop)
 	if len(droppable) > 0 {
 		p := droppable[mrand.Intn(len(droppable))]
+		if cm.penaltyFunc != nil {
+			var worst int64
+			for _, candidate := range droppable {
+				if penalty := cm.penaltyFunc(candidate.ID().String()); penalty > worst {
+					p, worst = candidate, penalty
+				}
+			}
+		}
 		log.Debug("Dropping random peer", "inbound", p.Inbound(),
 			"id", p.ID(), "duration", common.PrettyDuration(p.Lifetime()), "peercountbefore", len(peers))
 		p.Disconnect(p2p.DiscUselessPeer)
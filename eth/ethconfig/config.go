@@ -150,6 +150,15 @@ type Config struct {
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
 
+	// BlobValidationWorkers is the number of blob transaction batches that may be
+	// validated concurrently when received from the network (0 = number of CPUs).
+	BlobValidationWorkers int
+
+	// MaxTxFetchResponseRate is the number of pooled transaction requests served
+	// to a single peer per second, any more are answered with an empty reply
+	// (0 = unlimited).
+	MaxTxFetchResponseRate int
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
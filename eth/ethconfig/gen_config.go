@@ -49,6 +49,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		BlobPool                blobpool.Config
+		BlobValidationWorkers   int
+		MaxTxFetchResponseRate  int
 		GPO                     gasprice.Config
 		EnablePreimageRecording bool
 		EnableWitnessStats      bool
@@ -99,6 +101,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.BlobValidationWorkers = c.BlobValidationWorkers
+	enc.MaxTxFetchResponseRate = c.MaxTxFetchResponseRate
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableWitnessStats = c.EnableWitnessStats
@@ -153,6 +157,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		BlobPool                *blobpool.Config
+		BlobValidationWorkers   *int
+		MaxTxFetchResponseRate  *int
 		GPO                     *gasprice.Config
 		EnablePreimageRecording *bool
 		EnableWitnessStats      *bool
@@ -270,6 +276,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.BlobValidationWorkers != nil {
+		c.BlobValidationWorkers = *dec.BlobValidationWorkers
+	}
+	if dec.MaxTxFetchResponseRate != nil {
+		c.MaxTxFetchResponseRate = *dec.MaxTxFetchResponseRate
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
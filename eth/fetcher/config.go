@@ -0,0 +1,182 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TxFetcherConfig are the configuration parameters of the transaction fetcher.
+type TxFetcherConfig struct {
+	// BlobFetchBatchSize is the maximum number of blob transactions to request
+	// from a peer in a single GetPooledTransactions packet. Blob transactions
+	// weigh 128KB+ each, so fetching them one by one avoids hogging the peer.
+	BlobFetchBatchSize int
+
+	// LegacyFetchBatchSize is the maximum number of non-blob transactions to
+	// request from a peer in a single GetPooledTransactions packet.
+	LegacyFetchBatchSize int
+
+	// MaxPeerQueueBytes is the maximum number of bytes a single peer's pending
+	// announcements may account for, as estimated from the announced sizes.
+	// Announcements exceeding it are dropped until the peer's backlog drains.
+	// Zero disables the limit.
+	MaxPeerQueueBytes uint64
+
+	// BlobQueueWorkers is the maximum number of blob transaction batches that
+	// may be handed to the pool for (KZG) validation at the same time, across
+	// all peers.
+	//
+	// LegacyQueueWorkers is the same limit for batches of non-blob transactions.
+	// The two are separate so a burst of expensive blob validations does not
+	// hold up the import of cheap legacy transactions.
+	BlobQueueWorkers   int
+	LegacyQueueWorkers int
+
+	// MaxConcurrentKZGVerifications is the maximum number of blob transaction
+	// imports, and thus KZG proof verifications, that may run at the same time
+	// across all peers. The pool verifies the transactions of an imported batch
+	// one by one, so each in-flight blob import accounts for one verification.
+	// A blob import holds both a BlobQueueWorkers and a KZG slot, so the lower
+	// of the two limits is the effective one.
+	MaxConcurrentKZGVerifications int
+
+	// MaxMemoryBytes is the maximum number of bytes of delivered transactions
+	// that may be held in memory while waiting to be imported into the pool.
+	// Batches going over the allowance are written to a temporary spill buffer
+	// within SpillDir and imported once memory headroom recovers. Spilling is
+	// disabled unless both fields are set.
+	//
+	// MaxSpillBytes is the maximum number of bytes of transactions the spill
+	// buffer may hold on disk. Batches that would go over it are dropped and
+	// left to be fetched again later. Zero picks the default.
+	MaxMemoryBytes uint64
+	SpillDir       string
+	MaxSpillBytes  uint64
+
+	// MaxBodyCacheBytes is the maximum number of bytes of delivered non-blob
+	// transactions waiting to be imported into the pool that are indexed by hash,
+	// allowing ForceValidate to import them right away. Zero disables the cache.
+	MaxBodyCacheBytes uint64
+
+	// OnPeerRegistered, if set, is invoked when a peer first announces some
+	// transactions to the fetcher.
+	//
+	// OnPeerDropped, if set, is invoked when a registered peer is dropped from
+	// the fetcher, along with the reason of the removal.
+	//
+	// The hooks run on the fetcher's event loop without any locks held, so they
+	// must not block or call back into the fetcher synchronously.
+	OnPeerRegistered func(peer string)
+	OnPeerDropped    func(peer string, reason string)
+
+	// PeerPenaltyFn, if set, is invoked when a peer is observed misbehaving
+	// (e.g. flooding announcements, timing out on requests or delivering junk),
+	// with a score proportional to the severity of the offence. It allows the
+	// networking layer to deprioritize such peers without disconnecting them.
+	//
+	// The callback may be invoked concurrently from the event loop as well as
+	// the delivery paths, so it must be thread safe and must not block.
+	PeerPenaltyFn func(peer string, score int)
+
+	// ValidationLatencyWarnThreshold is the 99th percentile of the time between
+	// enqueueing delivered transactions and the pool finishing their validation,
+	// over a rolling 10 second window, above which the fetcher warns about the
+	// validation falling behind. Zero disables the warning.
+	ValidationLatencyWarnThreshold time.Duration
+
+	// EventLogPath, if set, is the file the fetcher appends a JSON line to for
+	// every Enqueue call, recording the delivering peer, the transaction hashes
+	// and their validation results, for post-mortem analysis. The entries are
+	// written asynchronously and dropped if the writer falls behind.
+	EventLogPath string
+
+	// RecycleBlobSidecars, if set, releases the sidecars of delivered blob
+	// transactions into types.DefaultBlobSidecarPool once the pool rejected them,
+	// so their buffers are reused when decoding subsequent deliveries. The
+	// rejected transactions are replaced by copies without sidecars in the slice
+	// passed to Enqueue. Enabling it also makes the decoding of blob transactions
+	// take its sidecars from the pool. It is off by default and may only be
+	// enabled if neither the import callback nor the caller of Enqueue retain
+	// the delivered transactions.
+	RecycleBlobSidecars bool
+
+	// Accept is a bitmap of the transaction types the local pool is interested in,
+	// laid out like txpool.ValidationOptions.Accept. Announcements of other types
+	// (as carried by eth/68 NewPooledTransactionHashes) are ignored and never
+	// fetched. Zero accepts all transaction types.
+	Accept uint8
+
+	// Logger, if set, replaces the node's root logger for the fetcher's messages,
+	// allowing them to be routed to a dedicated structured logging backend. The
+	// per-transaction records carry peer, txHash, txType and validationError
+	// attributes.
+	Logger *slog.Logger
+}
+
+// DefaultTxFetcherConfig contains the default configurations for the transaction
+// fetcher.
+var DefaultTxFetcherConfig = TxFetcherConfig{
+	BlobFetchBatchSize:   1,
+	LegacyFetchBatchSize: maxTxRetrievals,
+	BlobQueueWorkers:     runtime.NumCPU(),
+	LegacyQueueWorkers:   runtime.NumCPU(),
+
+	MaxConcurrentKZGVerifications: runtime.NumCPU(),
+
+	MaxSpillBytes: 1024 * 1024 * 1024,
+
+	ValidationLatencyWarnThreshold: 2 * time.Second,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *TxFetcherConfig) sanitize() TxFetcherConfig {
+	conf := *config
+	if conf.BlobFetchBatchSize < 1 {
+		log.Warn("Sanitizing invalid txfetcher blob batch size", "provided", conf.BlobFetchBatchSize, "updated", DefaultTxFetcherConfig.BlobFetchBatchSize)
+		conf.BlobFetchBatchSize = DefaultTxFetcherConfig.BlobFetchBatchSize
+	}
+	if conf.LegacyFetchBatchSize < 1 {
+		log.Warn("Sanitizing invalid txfetcher legacy batch size", "provided", conf.LegacyFetchBatchSize, "updated", DefaultTxFetcherConfig.LegacyFetchBatchSize)
+		conf.LegacyFetchBatchSize = DefaultTxFetcherConfig.LegacyFetchBatchSize
+	}
+	if conf.BlobQueueWorkers < 1 {
+		log.Warn("Sanitizing invalid txfetcher blob queue workers", "provided", conf.BlobQueueWorkers, "updated", DefaultTxFetcherConfig.BlobQueueWorkers)
+		conf.BlobQueueWorkers = DefaultTxFetcherConfig.BlobQueueWorkers
+	}
+	if conf.LegacyQueueWorkers < 1 {
+		log.Warn("Sanitizing invalid txfetcher legacy queue workers", "provided", conf.LegacyQueueWorkers, "updated", DefaultTxFetcherConfig.LegacyQueueWorkers)
+		conf.LegacyQueueWorkers = DefaultTxFetcherConfig.LegacyQueueWorkers
+	}
+	if conf.MaxConcurrentKZGVerifications < 1 {
+		log.Warn("Sanitizing invalid txfetcher concurrent KZG verifications", "provided", conf.MaxConcurrentKZGVerifications, "updated", DefaultTxFetcherConfig.MaxConcurrentKZGVerifications)
+		conf.MaxConcurrentKZGVerifications = DefaultTxFetcherConfig.MaxConcurrentKZGVerifications
+	}
+	if conf.MaxSpillBytes == 0 {
+		conf.MaxSpillBytes = DefaultTxFetcherConfig.MaxSpillBytes
+	}
+	if conf.ValidationLatencyWarnThreshold < 0 {
+		log.Warn("Sanitizing invalid txfetcher validation latency threshold", "provided", conf.ValidationLatencyWarnThreshold, "updated", DefaultTxFetcherConfig.ValidationLatencyWarnThreshold)
+		conf.ValidationLatencyWarnThreshold = DefaultTxFetcherConfig.ValidationLatencyWarnThreshold
+	}
+	return conf
+}
@@ -41,6 +41,16 @@ var (
 	txReplyUnderpricedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/underpriced", nil)
 	txReplyOtherRejectMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/otherreject", nil)
 
+	txSpillOutMeter  = metrics.NewRegisteredMeter("eth/fetcher/transaction/spill/out", nil)
+	txSpillInMeter   = metrics.NewRegisteredMeter("eth/fetcher/transaction/spill/in", nil)
+	txSpillDropMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/spill/dropped", nil)
+	txSpillBatches   = metrics.NewRegisteredGauge("eth/fetcher/transaction/spill/batches", nil)
+
+	txEventLogDropMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/eventlog/dropped", nil)
+
+	txQueueLegacyHist = metrics.NewRegisteredHistogram("eth/fetcher/transaction/queue/legacy", nil, metrics.NewExpDecaySample(1028, 0.015))
+	txQueueBlobHist   = metrics.NewRegisteredHistogram("eth/fetcher/transaction/queue/blob", nil, metrics.NewExpDecaySample(1028, 0.015))
+
 	txFetcherWaitingPeers   = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/peers", nil)
 	txFetcherWaitingHashes  = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/hashes", nil)
 	txFetcherQueueingPeers  = metrics.NewRegisteredGauge("eth/fetcher/transaction/queueing/peers", nil)
@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txBody is a delivered transaction waiting to be imported into the pool.
+type txBody struct {
+	tx    *types.Transaction
+	refs  int  // Number of pending imports delivering the transaction
+	taken bool // Whether the body was already handed out for a forced import
+}
+
+// txBodyCache indexes the bodies of delivered transactions that are waiting for
+// (or undergoing) their import into the pool, so ForceValidate can import them
+// right away instead of queueing behind other deliveries. Bodies are only held
+// for the duration of their import and the total size indexed is capped.
+//
+// Blob transactions are not indexed, since their sidecars may be recycled once
+// the delivering batch is rejected, while a forced import might still use them.
+//
+// A nil cache is valid and never holds any bodies.
+type txBodyCache struct {
+	bodies map[common.Hash]*txBody
+	size   uint64 // Total size of the indexed bodies
+	limit  uint64 // Maximum total size of the indexed bodies
+	lock   sync.Mutex
+}
+
+// newTxBodyCache creates a body cache holding up to limit bytes of transactions,
+// or returns nil if the limit is zero and the cache is disabled.
+func newTxBodyCache(limit uint64) *txBodyCache {
+	if limit == 0 {
+		return nil
+	}
+	return &txBodyCache{
+		bodies: make(map[common.Hash]*txBody),
+		limit:  limit,
+	}
+}
+
+// add indexes a batch of transactions about to be imported, returning the ones
+// that were indexed. Blob transactions and the ones that would push the cache
+// over its limit are skipped. The returned transactions must be removed once the import is done.
+func (c *txBodyCache) add(txs []*types.Transaction) []*types.Transaction {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var added []*types.Transaction
+	for _, tx := range txs {
+		if tx.Type() == types.BlobTxType {
+			continue
+		}
+		hash := tx.Hash()
+		if body, ok := c.bodies[hash]; ok {
+			body.refs++
+			added = append(added, tx)
+			continue
+		}
+		size := tx.Size()
+		if c.size+size > c.limit {
+			continue
+		}
+		c.bodies[hash] = &txBody{tx: tx, refs: 1}
+		c.size += size
+		added = append(added, tx)
+	}
+	return added
+}
+
+// remove drops a batch of transactions, as returned by add, from the index once
+// their import is done.
+func (c *txBodyCache) remove(txs []*types.Transaction) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, tx := range txs {
+		hash := tx.Hash()
+		body := c.bodies[hash]
+		if body.refs--; body.refs == 0 {
+			delete(c.bodies, hash)
+			c.size -= body.tx.Size()
+		}
+	}
+}
+
+// take returns the body of a pending transaction for a forced import, or nil
+// if it's not available. Each body is only handed out once.
+func (c *txBodyCache) take(hash common.Hash) *types.Transaction {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	body, ok := c.bodies[hash]
+	if !ok || body.taken {
+		return nil
+	}
+	body.taken = true
+	return body.tx
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// txEventLogQueue is the number of events buffered in memory for the event log
+// writer. Events arriving while the buffer is full are dropped rather than
+// stalling the delivery path.
+const txEventLogQueue = 1024
+
+// txEventSpilled is the validation result recorded for transactions deferred to
+// the spill buffer, which are only validated later on.
+const txEventSpilled = "spilled"
+
+// TxEvent is an entry of the transaction fetcher's event log, recording a single
+// Enqueue call along with the validation outcome of each delivered transaction.
+type TxEvent struct {
+	Time   time.Time     `json:"timestamp"`
+	Peer   string        `json:"peer"`
+	Hashes []common.Hash `json:"hashes"`
+	Direct bool          `json:"direct"`
+
+	// Errors holds the validation error of each transaction, in the order of the
+	// hashes. Accepted transactions have an empty entry, spilled ones "spilled".
+	Errors []string `json:"validation_errors"`
+}
+
+// txEventLog is an append-only JSON-lines file of Enqueue events, written on a
+// background goroutine.
+type txEventLog struct {
+	events chan *TxEvent
+	log    log.Logger    // Logger to report write failures to (nil = root logger)
+	done   chan struct{} // Closed when the writer flushed and closed the file
+}
+
+// newTxEventLog opens (or creates) the event log at the given path and starts
+// the background writer, which runs until quit is closed.
+func newTxEventLog(path string, logger log.Logger, quit chan struct{}) (*txEventLog, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l := &txEventLog{
+		events: make(chan *TxEvent, txEventLogQueue),
+		log:    logger,
+		done:   make(chan struct{}),
+	}
+	go l.loop(file, quit)
+	return l, nil
+}
+
+// push queues an event for writing, dropping it if the writer is falling behind.
+func (l *txEventLog) push(event *TxEvent) {
+	select {
+	case l.events <- event:
+	default:
+		txEventLogDropMeter.Mark(1)
+	}
+}
+
+// loop writes the queued events into the file, flushing whenever the queue is
+// drained, until the fetcher terminates.
+func (l *txEventLog) loop(file *os.File, quit chan struct{}) {
+	defer close(l.done)
+
+	var (
+		buf = bufio.NewWriter(file)
+		enc = json.NewEncoder(buf)
+	)
+	write := func(event *TxEvent) {
+		if err := enc.Encode(event); err != nil {
+			loggerOrRoot(l.log).Warn("Failed to write transaction event", "err", err)
+		}
+	}
+	flush := func() {
+		if err := buf.Flush(); err != nil {
+			loggerOrRoot(l.log).Warn("Failed to flush transaction event log", "err", err)
+		}
+	}
+	defer func() {
+		flush()
+		file.Close()
+	}()
+	for {
+		select {
+		case event := <-l.events:
+			write(event)
+			if len(l.events) == 0 {
+				flush()
+			}
+		case <-quit:
+			// Persist anything still queued before closing the file
+			for {
+				select {
+				case event := <-l.events:
+					write(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// ReadTxEventLog decodes the events of a transaction fetcher event log one by
+// one, invoking fn for each, until the log is exhausted or fn fails.
+func ReadTxEventLog(r io.Reader, fn func(*TxEvent) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		event := new(TxEvent)
+		if err := dec.Decode(event); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"math"
 	mrand "math/rand"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -37,8 +38,8 @@ const (
 	// can announce in a short time.
 	maxTxAnnounces = 4096
 
-	// maxTxRetrievals is the maximum number of transactions that can be fetched
-	// in one request. The rationale for picking 256 is to have a reasonabe lower
+	// maxTxRetrievals is the default maximum number of non-blob transactions that
+	// can be fetched in one request. The rationale for picking 256 is to have a reasonabe lower
 	// bound for the transferred data (don't waste RTTs, transfer more meaningful
 	// batch sizes), but also have an upper bound on the sequentiality to allow
 	// using our entire peerset for deliveries.
@@ -71,6 +72,24 @@ const (
 
 	// addTxsBatchSize it the max number of transactions to add in a single batch from a peer.
 	addTxsBatchSize = 128
+
+	// txSpillDrainInterval is the interval at which the spill buffer is checked
+	// for batches that can be imported into the pool.
+	txSpillDrainInterval = 100 * time.Millisecond
+
+	// txLocalPeer is the origin reported for transactions force imported from the
+	// local body cache, which are never penalized.
+	txLocalPeer = ""
+)
+
+// Penalty scores reported to TxFetcherConfig.PeerPenaltyFn for the various kinds
+// of misbehaviour, weighted by how likely they are to be malicious rather than
+// the result of a slow or racy but honest peer.
+const (
+	txPenaltyTimeout   = 2   // Transaction retrieval request timed out
+	txPenaltyStale     = 5   // Delivery dominated by transactions rejected by the pool
+	txPenaltyFlood     = 10  // Announcements over the per-peer count or byte limit
+	txPenaltyViolation = 100 // Protocol violation warranting a disconnect
 )
 
 var (
@@ -120,6 +139,13 @@ type txDelivery struct {
 	direct bool          // Whether this is a direct reply or a broadcast
 }
 
+// txAnnounceCount is a snapshot of the number of announced transactions not
+// yet delivered, in total and per announcing peer.
+type txAnnounceCount struct {
+	total   int
+	perPeer map[string]int
+}
+
 // txDrop is the notification that a peer has disconnected.
 type txDrop struct {
 	peer string
@@ -146,10 +172,14 @@ type TxFetcher struct {
 	notify  chan *txAnnounce
 	cleanup chan *txDelivery
 	drop    chan *txDrop
+	pause   chan bool
+	count   chan chan *txAnnounceCount
+	ping    chan chan struct{}
 	quit    chan struct{}
 
 	txSeq       uint64                             // Unique transaction sequence number
 	underpriced *lru.Cache[common.Hash, time.Time] // Transactions discarded as too cheap (don't re-fetch)
+	bodies      *txBodyCache                       // Delivered transactions pending import (nil if disabled)
 
 	// Stage 1: Waiting lists for newly discovered transactions that might be
 	// broadcast without needing explicit request/reply round trips.
@@ -169,12 +199,36 @@ type TxFetcher struct {
 	requests   map[string]*txRequest               // In-flight transaction retrievals
 	alternates map[common.Hash]map[string]struct{} // In-flight transaction alternate origins if retrieval fails
 
+	// Peer lifecycle tracking for the registration and teardown hooks
+	peers      map[string]struct{} // Set of peers having announced something since their last drop
+	violations map[string]string   // Reasons of the peer drops requested by the fetcher
+	peerCount  atomic.Int32        // Number of registered peers, readable outside the event loop
+
+	paused bool // Whether retrievals are suspended, announcements are still queued
+
+	blobWorkers   chan struct{}       // Semaphore limiting the concurrent blob transaction imports
+	legacyWorkers chan struct{}       // Semaphore limiting the concurrent non-blob transaction imports
+	kzgSlots      chan struct{}       // Semaphore limiting the concurrent KZG verifications of blob imports
+	importing     atomic.Uint64       // Bytes of delivered transactions currently being imported
+	importQueued  atomic.Int64        // Number of batches waiting for or undergoing validation
+	latency       txLatencyTracker    // Rolling window of validation latencies for slowness warnings
+	queueTimes    txQueueTimes        // Recent enqueue-to-validation delays per transaction kind
+	idle          txIdleTracker       // Delivered batches pending validation for IdleNotify callers
+	validations   txValidationTracker // Validation results for WaitForValidation callers
+	stats         txFetcherCounters   // Activity counters for Stats and ResetStats
+	spill         *txSpill            // Disk buffer for deliveries over the memory allowance (nil = disabled)
+	tracers       txTracers           // Debug traces of individual peers' activity
+	events        *txEventLog         // Structured log of the Enqueue calls (nil = disabled)
+
 	// Callbacks
 	validateMeta func(common.Hash, byte) error      // Validate a tx metadata based on the local txpool
 	addTxs       func([]*types.Transaction) []error // Insert a batch of transactions into local txpool
 	fetchTxs     func(string, []common.Hash) error  // Retrieves a set of txs from a remote peer
 	dropPeer     func(string)                       // Drops a peer in case of announcement violation
 
+	config TxFetcherConfig // Tunable parameters of the fetcher
+	log    log.Logger      // Structured logger from the config (nil = root logger)
+
 	step     chan struct{}    // Notification channel when the fetcher loop iterates
 	clock    mclock.Clock     // Monotonic clock or simulated clock for tests
 	realTime func() time.Time // Real system time or simulated time for tests
@@ -184,7 +238,13 @@ type TxFetcher struct {
 // NewTxFetcher creates a transaction fetcher to retrieve transaction
 // based on hash announcements.
 func NewTxFetcher(validateMeta func(common.Hash, byte) error, addTxs func([]*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string)) *TxFetcher {
-	return NewTxFetcherForTests(validateMeta, addTxs, fetchTxs, dropPeer, mclock.System{}, time.Now, nil)
+	return NewTxFetcherWithConfig(DefaultTxFetcherConfig, validateMeta, addTxs, fetchTxs, dropPeer)
+}
+
+// NewTxFetcherWithConfig creates a transaction fetcher with custom configuration
+// parameters to retrieve transaction based on hash announcements.
+func NewTxFetcherWithConfig(config TxFetcherConfig, validateMeta func(common.Hash, byte) error, addTxs func([]*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string)) *TxFetcher {
+	return newTxFetcher(config, validateMeta, addTxs, fetchTxs, dropPeer, mclock.System{}, time.Now, nil)
 }
 
 // NewTxFetcherForTests is a testing method to mock out the realtime clock with
@@ -192,27 +252,85 @@ func NewTxFetcher(validateMeta func(common.Hash, byte) error, addTxs func([]*typ
 func NewTxFetcherForTests(
 	validateMeta func(common.Hash, byte) error, addTxs func([]*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string),
 	clock mclock.Clock, realTime func() time.Time, rand *mrand.Rand) *TxFetcher {
+	return newTxFetcher(DefaultTxFetcherConfig, validateMeta, addTxs, fetchTxs, dropPeer, clock, realTime, rand)
+}
+
+// loggerOrRoot returns the given logger, falling back to the root logger if
+// none was configured.
+func loggerOrRoot(logger log.Logger) log.Logger {
+	if logger != nil {
+		return logger
+	}
+	return log.Root()
+}
+
+// logger returns the logger the fetcher should emit its messages through.
+func (f *TxFetcher) logger() log.Logger {
+	return loggerOrRoot(f.log)
+}
+
+// newTxFetcher creates a transaction fetcher with all the configurable knobs
+// and testing hooks exposed.
+func newTxFetcher(config TxFetcherConfig,
+	validateMeta func(common.Hash, byte) error, addTxs func([]*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string),
+	clock mclock.Clock, realTime func() time.Time, rand *mrand.Rand) *TxFetcher {
+	config = config.sanitize()
+
+	var logger log.Logger
+	if config.Logger != nil {
+		logger = log.NewLogger(config.Logger.Handler())
+	}
+
+	if config.RecycleBlobSidecars {
+		types.DefaultBlobSidecarPool.Enable()
+	}
+	var spill *txSpill
+	if config.MaxMemoryBytes > 0 && config.SpillDir != "" {
+		spill = newTxSpill(config.SpillDir, config.MaxSpillBytes)
+	}
+	quit := make(chan struct{})
+
+	var events *txEventLog
+	if config.EventLogPath != "" {
+		var err error
+		if events, err = newTxEventLog(config.EventLogPath, logger, quit); err != nil {
+			loggerOrRoot(logger).Error("Failed to open transaction event log", "path", config.EventLogPath, "err", err)
+		}
+	}
 	return &TxFetcher{
-		notify:       make(chan *txAnnounce),
-		cleanup:      make(chan *txDelivery),
-		drop:         make(chan *txDrop),
-		quit:         make(chan struct{}),
-		waitlist:     make(map[common.Hash]map[string]struct{}),
-		waittime:     make(map[common.Hash]mclock.AbsTime),
-		waitslots:    make(map[string]map[common.Hash]*txMetadataWithSeq),
-		announces:    make(map[string]map[common.Hash]*txMetadataWithSeq),
-		announced:    make(map[common.Hash]map[string]struct{}),
-		fetching:     make(map[common.Hash]string),
-		requests:     make(map[string]*txRequest),
-		alternates:   make(map[common.Hash]map[string]struct{}),
-		underpriced:  lru.NewCache[common.Hash, time.Time](maxTxUnderpricedSetSize),
-		validateMeta: validateMeta,
-		addTxs:       addTxs,
-		fetchTxs:     fetchTxs,
-		dropPeer:     dropPeer,
-		clock:        clock,
-		realTime:     realTime,
-		rand:         rand,
+		notify:        make(chan *txAnnounce),
+		cleanup:       make(chan *txDelivery),
+		drop:          make(chan *txDrop),
+		pause:         make(chan bool),
+		count:         make(chan chan *txAnnounceCount),
+		ping:          make(chan chan struct{}),
+		quit:          quit,
+		waitlist:      make(map[common.Hash]map[string]struct{}),
+		waittime:      make(map[common.Hash]mclock.AbsTime),
+		waitslots:     make(map[string]map[common.Hash]*txMetadataWithSeq),
+		announces:     make(map[string]map[common.Hash]*txMetadataWithSeq),
+		announced:     make(map[common.Hash]map[string]struct{}),
+		fetching:      make(map[common.Hash]string),
+		requests:      make(map[string]*txRequest),
+		alternates:    make(map[common.Hash]map[string]struct{}),
+		peers:         make(map[string]struct{}),
+		violations:    make(map[string]string),
+		underpriced:   lru.NewCache[common.Hash, time.Time](maxTxUnderpricedSetSize),
+		bodies:        newTxBodyCache(config.MaxBodyCacheBytes),
+		validateMeta:  validateMeta,
+		addTxs:        addTxs,
+		fetchTxs:      fetchTxs,
+		dropPeer:      dropPeer,
+		blobWorkers:   make(chan struct{}, config.BlobQueueWorkers),
+		legacyWorkers: make(chan struct{}, config.LegacyQueueWorkers),
+		kzgSlots:      make(chan struct{}, config.MaxConcurrentKZGVerifications),
+		spill:         spill,
+		events:        events,
+		config:        config,
+		log:           logger,
+		clock:         clock,
+		realTime:      realTime,
+		rand:          rand,
 	}
 }
 
@@ -221,6 +339,7 @@ func NewTxFetcherForTests(
 func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []common.Hash) error {
 	// Keep track of all the announced transactions
 	txAnnounceInMeter.Mark(int64(len(hashes)))
+	f.stats.announces.Add(uint64(len(hashes)))
 
 	// Skip any transaction announcements that we already know of, or that we've
 	// previously marked as cheap and discarded. This check is of course racy,
@@ -235,6 +354,9 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 		underpriced int64
 	)
 	for i, hash := range hashes {
+		if !f.accepts(types[i]) {
+			continue
+		}
 		err := f.validateMeta(hash, types[i])
 		if errors.Is(err, txpool.ErrAlreadyKnown) {
 			duplicate++
@@ -248,6 +370,10 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 			underpriced++
 			continue
 		}
+		if f.spill != nil && f.spill.has(hash) {
+			duplicate++
+			continue
+		}
 
 		unknownHashes = append(unknownHashes, hash)
 
@@ -272,6 +398,15 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 	}
 }
 
+// accepts reports whether transactions of the given type are to be retrieved,
+// as configured by the Accept bitmap.
+func (f *TxFetcher) accepts(kind byte) bool {
+	if f.config.Accept == 0 {
+		return true
+	}
+	return kind < 8 && f.config.Accept&(1<<kind) != 0
+}
+
 // isKnownUnderpriced reports whether a transaction hash was recently found to be underpriced.
 func (f *TxFetcher) isKnownUnderpriced(hash common.Hash) bool {
 	prevTime, ok := f.underpriced.Peek(hash)
@@ -287,26 +422,22 @@ func (f *TxFetcher) isKnownUnderpriced(hash common.Hash) bool {
 // direct request replies. The differentiation is important so the fetcher can
 // re-schedule missing transactions as soon as possible.
 func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool) error {
-	var (
-		inMeter          = txReplyInMeter
-		knownMeter       = txReplyKnownMeter
-		underpricedMeter = txReplyUnderpricedMeter
-		otherRejectMeter = txReplyOtherRejectMeter
-	)
+	// Keep track of all the propagated transactions
+	inMeter := txReplyInMeter
 	if !direct {
 		inMeter = txBroadcastInMeter
-		knownMeter = txBroadcastKnownMeter
-		underpricedMeter = txBroadcastUnderpricedMeter
-		otherRejectMeter = txBroadcastOtherRejectMeter
 	}
-	// Keep track of all the propagated transactions
 	inMeter.Mark(int64(len(txs)))
+	f.stats.deliveries.Add(uint64(len(txs)))
+	f.trace(peer, "enqueue %d txs (direct: %v)", len(txs), direct)
+	start := f.clock.Now()
 
 	// Push all the transactions into the pool, tracking underpriced ones to avoid
 	// re-requesting them and dropping the peer in case of malicious transfers.
 	var (
-		added = make([]common.Hash, 0, len(txs))
-		metas = make([]txMetadata, 0, len(txs))
+		added   = make([]common.Hash, 0, len(txs))
+		metas   = make([]txMetadata, 0, len(txs))
+		results []string // Validation results for the event log, if enabled
 	)
 	// proceed in batches
 	for i := 0; i < len(txs); i += addTxsBatchSize {
@@ -314,32 +445,44 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		if end > len(txs) {
 			end = len(txs)
 		}
-		var (
-			duplicate   int64
-			underpriced int64
-			otherreject int64
-		)
 		batch := txs[i:end]
-
-		for j, err := range f.addTxs(batch) {
-			// Track the transaction hash if the price is too low for us.
-			// Avoid re-request this transaction when we receive another
-			// announcement.
-			if errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow) {
-				f.underpriced.Add(batch[j].Hash(), batch[j].Time())
+		f.idle.add()
+
+		// If importing the batch would go over the memory allowance, defer it
+		// to the spill buffer, but consider it delivered to avoid re-requests.
+		// If the spill buffer is full too, drop the batch and leave it to be
+		// fetched again later.
+		if deferred, spilled := f.spillTxs(peer, batch, direct); deferred {
+			if !spilled {
+				f.trace(peer, "drop %d txs", len(batch))
+				f.idle.done()
+				continue
 			}
-			// Track a few interesting failure types
-			switch {
-			case err == nil: // Noop, but need to handle to not count these
-
-			case errors.Is(err, txpool.ErrAlreadyKnown):
-				duplicate++
-
-			case errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow):
-				underpriced++
-
-			default:
-				otherreject++
+			f.trace(peer, "spill %d txs", len(batch))
+			for _, tx := range batch {
+				if f.events != nil {
+					results = append(results, txEventSpilled)
+				}
+				added = append(added, tx.Hash())
+				metas = append(metas, txMetadata{
+					kind: tx.Type(),
+					size: uint32(tx.Size()),
+				})
+			}
+			continue
+		}
+		pending := f.bodies.add(batch)
+		errs := f.importTxs(start, batch)
+		f.bodies.remove(pending)
+		f.trackLatency(start, len(batch))
+
+		for j, err := range errs {
+			if f.events != nil {
+				var result string
+				if err != nil {
+					result = err.Error()
+				}
+				results = append(results, result)
 			}
 			added = append(added, batch[j].Hash())
 			metas = append(metas, txMetadata{
@@ -347,16 +490,23 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 				size: uint32(batch[j].Size()),
 			})
 		}
-		knownMeter.Mark(duplicate)
-		underpricedMeter.Mark(underpriced)
-		otherRejectMeter.Mark(otherreject)
+		otherreject := f.processResults(peer, batch, errs, direct)
+		f.idle.done()
 
 		// If 'other reject' is >25% of the deliveries in any batch, sleep a bit.
 		if otherreject > addTxsBatchSize/4 {
 			time.Sleep(200 * time.Millisecond)
-			log.Debug("Peer delivering stale transactions", "peer", peer, "rejected", otherreject)
 		}
 	}
+	if f.events != nil {
+		f.events.push(&TxEvent{
+			Time:   f.realTime(),
+			Peer:   peer,
+			Hashes: added,
+			Direct: direct,
+			Errors: results,
+		})
+	}
 	select {
 	case f.cleanup <- &txDelivery{origin: peer, hashes: added, metas: metas, direct: direct}:
 		return nil
@@ -365,6 +515,259 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 	}
 }
 
+// processResults accounts for the pool's verdicts on a batch of transactions
+// delivered by a peer: it remembers the underpriced hashes to avoid re-requesting
+// them, updates the meters and penalizes the peer for junk deliveries. The number of transactions rejected for reasons other
+// than being known or underpriced is returned.
+func (f *TxFetcher) processResults(peer string, batch []*types.Transaction, errs []error, direct bool) int64 {
+	var (
+		knownMeter       = txReplyKnownMeter
+		underpricedMeter = txReplyUnderpricedMeter
+		otherRejectMeter = txReplyOtherRejectMeter
+	)
+	if !direct {
+		knownMeter = txBroadcastKnownMeter
+		underpricedMeter = txBroadcastUnderpricedMeter
+		otherRejectMeter = txBroadcastOtherRejectMeter
+	}
+	var (
+		duplicate   int64
+		underpriced int64
+		otherreject int64
+	)
+	for j, err := range errs {
+		if err != nil {
+			f.trace(peer, "reject %x: %v", batch[j].Hash(), err)
+			f.logger().Trace("Rejected delivered transaction", "peer", peer, "txHash", batch[j].Hash(), "txType", types.TransactionTypeName(batch[j].Type()), "validationError", err)
+		} else {
+			f.trace(peer, "accept %x", batch[j].Hash())
+		}
+		// Track the transaction hash if the price is too low for us.
+		// Avoid re-request this transaction when we receive another
+		// announcement.
+		if errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow) {
+			f.underpriced.Add(batch[j].Hash(), batch[j].Time())
+		}
+		// Track a few interesting failure types
+		switch {
+		case err == nil: // Noop, but need to handle to not count these
+
+		case errors.Is(err, txpool.ErrAlreadyKnown):
+			duplicate++
+
+		case errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow):
+			underpriced++
+
+		case errors.Is(err, txpool.ErrBlobPoolFull):
+			// The pool is at capacity, the peer is not at fault
+
+		default:
+			otherreject++
+		}
+	}
+	// Recycle the sidecars of the rejected blob transactions, unless a timed
+	// out KZG verification might still be reading them. The sidecars are
+	// detached from the delivered transactions before being released.
+	if f.config.RecycleBlobSidecars {
+		for j, err := range errs {
+			if err != nil && !errors.Is(err, txpool.ErrKZGTimeout) && batch[j].BlobTxSidecar() != nil {
+				sidecar := batch[j].BlobTxSidecar()
+				batch[j] = batch[j].WithoutBlobTxSidecar()
+				types.DefaultBlobSidecarPool.Put(sidecar)
+			}
+		}
+	}
+	knownMeter.Mark(duplicate)
+	underpricedMeter.Mark(underpriced)
+	otherRejectMeter.Mark(otherreject)
+	f.stats.known.Add(uint64(duplicate))
+	f.stats.underpriced.Add(uint64(underpriced))
+	f.stats.rejected.Add(uint64(otherreject))
+
+	if otherreject > addTxsBatchSize/4 {
+		f.penalize(peer, txPenaltyStale)
+		f.logger().Debug("Peer delivering stale transactions", "peer", peer, "rejected", otherreject)
+	}
+	return otherreject
+}
+
+// importTxs pushes a batch of transactions, enqueued at the given time, into the
+// pool. Blob and non-blob transactions are imported separately, each counting
+// against its own worker limit, so cheap imports don't queue up behind the
+// expensive KZG validations.
+func (f *TxFetcher) importTxs(start mclock.AbsTime, batch []*types.Transaction) []error {
+	var (
+		size   uint64
+		blobs  []*types.Transaction
+		legacy []*types.Transaction
+	)
+	for _, tx := range batch {
+		size += tx.Size()
+		if tx.HasBlobs() {
+			blobs = append(blobs, tx)
+		} else {
+			legacy = append(legacy, tx)
+		}
+	}
+	f.importing.Add(size)
+	defer f.importing.Add(^(size - 1))
+
+	// Short circuit the common case of a single kind of transactions
+	switch {
+	case len(blobs) == 0:
+		return f.importQueue(start, f.legacyWorkers, batch)
+	case len(legacy) == 0:
+		return f.importQueue(start, f.blobWorkers, batch)
+	}
+	// Mixed batch, import the legacy transactions first and stitch the errors
+	// back into the original order
+	var (
+		legacyErrs = f.importQueue(start, f.legacyWorkers, legacy)
+		blobErrs   = f.importQueue(start, f.blobWorkers, blobs)
+		errs       = make([]error, 0, len(batch))
+	)
+	for _, tx := range batch {
+		if tx.HasBlobs() {
+			errs, blobErrs = append(errs, blobErrs[0]), blobErrs[1:]
+		} else {
+			errs, legacyErrs = append(errs, legacyErrs[0]), legacyErrs[1:]
+		}
+	}
+	return errs
+}
+
+// importQueue pushes a batch of transactions into the pool once a worker slot
+// of the given queue is available, recording the time they spent queueing since
+// being enqueued.
+func (f *TxFetcher) importQueue(start mclock.AbsTime, workers chan struct{}, txs []*types.Transaction) []error {
+	f.importQueued.Add(1)
+	defer f.importQueued.Add(-1)
+
+	workers <- struct{}{}
+	defer func() { <-workers }()
+
+	// Blob imports additionally count against the global KZG verification limit
+	if workers == f.blobWorkers {
+		f.kzgSlots <- struct{}{}
+		defer func() { <-f.kzgSlots }()
+	}
+	f.queueTimes.record(txs, f.clock.Now().Sub(start))
+
+	errs := f.addTxs(txs)
+	f.validations.complete(txs, errs)
+	return errs
+}
+
+// spillTxs defers a batch of transactions to the spill buffer if importing it
+// right away would push the memory held by in-flight imports over the allowance.
+// The method returns whether the batch was held back from the import and, if so,
+// whether it was accepted by the spill buffer or dropped for being over its limit.
+func (f *TxFetcher) spillTxs(peer string, batch []*types.Transaction, direct bool) (bool, bool) {
+	if f.spill == nil {
+		return false, false
+	}
+	var size uint64
+	for _, tx := range batch {
+		size += tx.Size()
+	}
+	if f.importing.Load()+size <= f.config.MaxMemoryBytes {
+		return false, false
+	}
+	if !f.spill.push(peer, direct, batch) {
+		f.logger().Debug("Dropped transactions over the spill limit", "peer", peer, "count", len(batch))
+		txSpillDropMeter.Mark(int64(len(batch)))
+		return true, false
+	}
+	txSpillOutMeter.Mark(int64(len(batch)))
+	f.stats.spilled.Add(uint64(len(batch)))
+	return true, true
+}
+
+// spillLoop writes the batches queued by spillTxs to disk, and periodically
+// moves the spilled batches back into the pool while there is memory headroom
+// available. Drained batches go through the same result accounting as direct
+// deliveries.
+func (f *TxFetcher) spillLoop() {
+	defer f.spill.close()
+
+	ticker := time.NewTicker(txSpillDrainInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case batch := <-f.spill.queue:
+			if err := f.spill.write(batch); err != nil {
+				f.logger().Warn("Failed to spill transactions to disk", "peer", batch.peer, "err", err)
+				txSpillDropMeter.Mark(int64(len(batch.txs)))
+				f.idle.done()
+				continue
+			}
+			txSpillBatches.Update(int64(f.spill.len()))
+
+		case <-ticker.C:
+			for f.importing.Load() < f.config.MaxMemoryBytes {
+				peer, direct, txs, err := f.spill.pop()
+				if err != nil {
+					f.logger().Warn("Failed to load spilled transactions", "err", err)
+					f.idle.done()
+					continue
+				}
+				if txs == nil {
+					break
+				}
+				txSpillInMeter.Mark(int64(len(txs)))
+				txSpillBatches.Update(int64(f.spill.len()))
+
+				errs := f.importTxs(f.clock.Now(), txs)
+				f.processResults(peer, txs, errs, direct)
+
+				hashes := make([]common.Hash, len(txs))
+				for i, tx := range txs {
+					hashes[i] = tx.Hash()
+				}
+				f.spill.forget(hashes)
+
+				f.logger().Trace("Imported spilled transactions", "peer", peer, "count", len(txs))
+				f.idle.done()
+			}
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// ForceValidate imports a delivered transaction that is still waiting for its
+// turn to be imported into the pool right away, skipping both the import queue
+// and the fetch of any pending announcement of it. This allows transactions
+// re-entering the mempool, e.g. after a reorg, to be validated without waiting
+// on the network or on other deliveries. The method returns false if the body
+// cache is disabled (see TxFetcherConfig.MaxBodyCacheBytes) or the transaction
+// body is not available locally.
+//
+// The import is treated as a broadcast, so any tracked announcement or in-flight
+// retrieval of the transaction is cleaned up once it's done.
+func (f *TxFetcher) ForceValidate(hash common.Hash) bool {
+	tx := f.bodies.take(hash)
+	if tx == nil {
+		return false
+	}
+	txs := []*types.Transaction{tx}
+
+	f.idle.add()
+	errs := f.addTxs(txs)
+	f.validations.complete(txs, errs)
+	f.processResults(txLocalPeer, txs, errs, false)
+	f.idle.done()
+
+	meta := txMetadata{kind: tx.Type(), size: uint32(tx.Size())}
+	select {
+	case f.cleanup <- &txDelivery{origin: txLocalPeer, hashes: []common.Hash{hash}, metas: []txMetadata{meta}}:
+		return true
+	case <-f.quit:
+		return false
+	}
+}
+
 // Drop should be called when a peer disconnects. It cleans up all the internal
 // data structures of the given node.
 func (f *TxFetcher) Drop(peer string) error {
@@ -376,10 +779,76 @@ func (f *TxFetcher) Drop(peer string) error {
 	}
 }
 
+// AnnouncedCount returns the number of unique transactions announced to the
+// fetcher but not yet delivered (waiting, queued or being fetched), along with
+// the number of such announcements tracked per peer. Since multiple peers may
+// announce the same transaction, the per-peer counts may add up to more than
+// the total.
+func (f *TxFetcher) AnnouncedCount() (int, map[string]int) {
+	res := make(chan *txAnnounceCount, 1)
+	select {
+	case f.count <- res:
+		count := <-res
+		return count.total, count.perPeer
+	case <-f.quit:
+		return 0, nil
+	}
+}
+
+// Pause suspends the retrieval of announced transactions, e.g. for the duration
+// of a maintenance window. Announcements keep being accepted and queued up, but
+// no new requests are dispatched to peers until Resume is called. Requests that
+// are already in flight are allowed to finish.
+func (f *TxFetcher) Pause() error {
+	select {
+	case f.pause <- true:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// Resume restarts the retrieval of announced transactions after a Pause, and
+// immediately schedules fetches for everything queued up in the meantime.
+func (f *TxFetcher) Resume() error {
+	select {
+	case f.pause <- false:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// Healthy checks whether the event loop of the fetcher is responsive by sending
+// it a no-op ping and waiting up to the given timeout for the reply. It returns
+// false if the loop is stuck, not yet started or already terminated.
+func (f *TxFetcher) Healthy(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	res := make(chan struct{}, 1)
+	select {
+	case f.ping <- res:
+	case <-timer.C:
+		return false
+	case <-f.quit:
+		return false
+	}
+	select {
+	case <-res:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
 // Start boots up the announcement based synchroniser, accepting and processing
 // hash notifications and block fetches until termination requested.
 func (f *TxFetcher) Start() {
 	go f.loop()
+	if f.spill != nil {
+		go f.spillLoop()
+	}
 }
 
 // Stop terminates the announcement based synchroniser, canceling all pending
@@ -399,6 +868,14 @@ func (f *TxFetcher) loop() {
 	for {
 		select {
 		case ann := <-f.notify:
+			// Register the peer if this is its first announcement
+			if _, ok := f.peers[ann.origin]; !ok {
+				f.peers[ann.origin] = struct{}{}
+				f.peerCount.Add(1)
+				if f.config.OnPeerRegistered != nil {
+					f.config.OnPeerRegistered(ann.origin)
+				}
+			}
 			// Drop part of the new announcements if there are too many accumulated.
 			// Note, we could but do not filter already known transactions here as
 			// the probability of something arriving between this call and the pre-
@@ -410,15 +887,41 @@ func (f *TxFetcher) loop() {
 				// check. Should be fine as the limit is in the thousands and the
 				// request size in the hundreds.
 				txAnnounceDOSMeter.Mark(int64(len(ann.hashes)))
+				f.penalize(ann.origin, txPenaltyFlood)
 				break
 			}
 			want := used + len(ann.hashes)
 			if want > maxTxAnnounces {
 				txAnnounceDOSMeter.Mark(int64(want - maxTxAnnounces))
+				f.penalize(ann.origin, txPenaltyFlood)
 
 				ann.hashes = ann.hashes[:maxTxAnnounces-used]
 				ann.metas = ann.metas[:maxTxAnnounces-used]
 			}
+			// Drop the announcements that would push the memory contributed by
+			// the peer over the allowance, until its backlog drains.
+			if limit := f.config.MaxPeerQueueBytes; limit > 0 {
+				queued := f.queuedBytes(ann.origin)
+
+				keep := 0
+				for ; keep < len(ann.metas); keep++ {
+					if queued+uint64(ann.metas[keep].size) > limit {
+						break
+					}
+					queued += uint64(ann.metas[keep].size)
+				}
+				if keep < len(ann.hashes) {
+					f.logger().Warn("Peer transaction queue over byte limit", "peer", ann.origin, "queued", queued, "limit", limit, "dropped", len(ann.hashes)-keep)
+					txAnnounceDOSMeter.Mark(int64(len(ann.hashes) - keep))
+					f.penalize(ann.origin, txPenaltyFlood)
+
+					ann.hashes = ann.hashes[:keep]
+					ann.metas = ann.metas[:keep]
+				}
+				if keep == 0 {
+					break
+				}
+			}
 			// All is well, schedule the remainder of the transactions
 			var (
 				idleWait   = len(f.waittime) == 0
@@ -583,6 +1086,7 @@ func (f *TxFetcher) loop() {
 			for peer, req := range f.requests {
 				if time.Duration(f.clock.Now()-req.time)+txGatherSlack > txFetchTimeout {
 					txRequestTimeoutMeter.Mark(int64(len(req.hashes)))
+					f.stats.timeouts.Add(uint64(len(req.hashes)))
 
 					// Reschedule all the not-yet-delivered fetches to alternate peers
 					for _, hash := range req.hashes {
@@ -613,6 +1117,7 @@ func (f *TxFetcher) loop() {
 					// Keep track of the request as dangling, but never expire
 					f.requests[peer].hashes = nil
 					txFetcherSlowPeers.Inc(1)
+					f.penalize(peer, txPenaltyTimeout)
 				}
 			}
 			// Schedule a new transaction retrieval
@@ -631,18 +1136,18 @@ func (f *TxFetcher) loop() {
 					for peer, txset := range f.waitslots {
 						if meta := txset[hash]; meta != nil {
 							if delivery.metas[i].kind != meta.kind {
-								log.Warn("Announced transaction type mismatch", "peer", peer, "tx", hash, "type", delivery.metas[i].kind, "ann", meta.kind)
-								f.dropPeer(peer)
+								f.logger().Warn("Announced transaction type mismatch", "peer", peer, "tx", hash, "type", types.TransactionTypeName(delivery.metas[i].kind), "ann", types.TransactionTypeName(meta.kind))
+								f.requestDrop(peer, "announced type mismatch")
 							} else if delivery.metas[i].size != meta.size {
 								if math.Abs(float64(delivery.metas[i].size)-float64(meta.size)) > 8 {
-									log.Warn("Announced transaction size mismatch", "peer", peer, "tx", hash, "size", delivery.metas[i].size, "ann", meta.size)
+									f.logger().Warn("Announced transaction size mismatch", "peer", peer, "tx", hash, "size", delivery.metas[i].size, "ann", meta.size)
 
 									// Normally we should drop a peer considering this is a protocol violation.
 									// However, due to the RLP vs consensus format messyness, allow a few bytes
 									// wiggle-room where we only warn, but don't drop.
 									//
 									// TODO(karalabe): Get rid of this relaxation when clients are proven stable.
-									f.dropPeer(peer)
+									f.requestDrop(peer, "announced size mismatch")
 								}
 							}
 						}
@@ -657,18 +1162,18 @@ func (f *TxFetcher) loop() {
 					for peer, txset := range f.announces {
 						if meta := txset[hash]; meta != nil {
 							if delivery.metas[i].kind != meta.kind {
-								log.Warn("Announced transaction type mismatch", "peer", peer, "tx", hash, "type", delivery.metas[i].kind, "ann", meta.kind)
-								f.dropPeer(peer)
+								f.logger().Warn("Announced transaction type mismatch", "peer", peer, "tx", hash, "type", types.TransactionTypeName(delivery.metas[i].kind), "ann", types.TransactionTypeName(meta.kind))
+								f.requestDrop(peer, "announced type mismatch")
 							} else if delivery.metas[i].size != meta.size {
 								if math.Abs(float64(delivery.metas[i].size)-float64(meta.size)) > 8 {
-									log.Warn("Announced transaction size mismatch", "peer", peer, "tx", hash, "size", delivery.metas[i].size, "ann", meta.size)
+									f.logger().Warn("Announced transaction size mismatch", "peer", peer, "tx", hash, "size", delivery.metas[i].size, "ann", meta.size)
 
 									// Normally we should drop a peer considering this is a protocol violation.
 									// However, due to the RLP vs consensus format messyness, allow a few bytes
 									// wiggle-room where we only warn, but don't drop.
 									//
 									// TODO(karalabe): Get rid of this relaxation when clients are proven stable.
-									f.dropPeer(peer)
+									f.requestDrop(peer, "announced size mismatch")
 								}
 							}
 						}
@@ -703,7 +1208,7 @@ func (f *TxFetcher) loop() {
 				// Make sure something was pending, nuke it
 				req := f.requests[delivery.origin]
 				if req == nil {
-					log.Warn("Unexpected transaction delivery", "peer", delivery.origin)
+					f.logger().Warn("Unexpected transaction delivery", "peer", delivery.origin)
 					break
 				}
 				if req.hashes == nil {
@@ -814,6 +1319,51 @@ func (f *TxFetcher) loop() {
 				f.scheduleFetches(timeoutTimer, timeoutTrigger, nil)
 				f.rescheduleTimeout(timeoutTimer, timeoutTrigger)
 			}
+			// Notify any listener that the peer was torn down
+			reason, ok := f.violations[drop.peer]
+			if !ok {
+				reason = "disconnected"
+			}
+			f.trace(drop.peer, "drop: %s", reason)
+
+			if _, ok := f.peers[drop.peer]; ok {
+				delete(f.peers, drop.peer)
+				f.peerCount.Add(-1)
+				if f.config.OnPeerDropped != nil {
+					f.config.OnPeerDropped(drop.peer, reason)
+				}
+			}
+			delete(f.violations, drop.peer)
+
+		case res := <-f.count:
+			count := &txAnnounceCount{
+				total:   len(f.waitlist) + len(f.announced) + len(f.fetching),
+				perPeer: make(map[string]int),
+			}
+			for peer, hashes := range f.waitslots {
+				count.perPeer[peer] += len(hashes)
+			}
+			for peer, hashes := range f.announces {
+				count.perPeer[peer] += len(hashes)
+			}
+			res <- count
+			continue // Nothing changed, don't bump the metrics nor step the tests
+
+		case res := <-f.ping:
+			res <- struct{}{}
+			continue // Nothing changed, don't bump the metrics nor step the tests
+
+		case paused := <-f.pause:
+			if f.paused == paused {
+				break
+			}
+			f.paused = paused
+			if paused {
+				f.logger().Info("Transaction fetching paused")
+			} else {
+				f.logger().Info("Transaction fetching resumed")
+				f.scheduleFetches(timeoutTimer, timeoutTrigger, nil)
+			}
 
 		case <-f.quit:
 			return
@@ -899,6 +1449,10 @@ func (f *TxFetcher) rescheduleTimeout(timer *mclock.Timer, trigger chan struct{}
 
 // scheduleFetches starts a batch of retrievals for all available idle peers.
 func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{}, whitelist map[string]struct{}) {
+	// Don't dispatch anything while retrievals are paused, Resume reschedules
+	if f.paused {
+		return
+	}
 	// Gather the set of peers we want to retrieve from (default to all)
 	actives := whitelist
 	if actives == nil {
@@ -921,14 +1475,22 @@ func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{},
 			return // continue in the for-each
 		}
 		var (
-			hashes = make([]common.Hash, 0, maxTxRetrievals)
+			hashes = make([]common.Hash, 0, f.config.LegacyFetchBatchSize)
 			bytes  uint64
+			blobs  int
+			others int
 		)
 		f.forEachAnnounce(f.announces[peer], func(hash common.Hash, meta txMetadata) bool {
 			// If the transaction is already fetching, skip to the next one
 			if _, ok := f.fetching[hash]; ok {
 				return true
 			}
+			// If the batch limit for the transaction's type was already reached,
+			// skip to the next one (it might be of a different type)
+			isBlob := meta.kind == types.BlobTxType
+			if (isBlob && blobs >= f.config.BlobFetchBatchSize) || (!isBlob && others >= f.config.LegacyFetchBatchSize) {
+				return true
+			}
 			// Mark the hash as fetching and stash away possible alternates
 			f.fetching[hash] = peer
 
@@ -938,9 +1500,14 @@ func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{},
 			f.alternates[hash] = f.announced[hash]
 			delete(f.announced, hash)
 
-			// Accumulate the hash and stop if the limit was reached
+			// Accumulate the hash and stop if all the limits were reached
 			hashes = append(hashes, hash)
-			if len(hashes) >= maxTxRetrievals {
+			if isBlob {
+				blobs++
+			} else {
+				others++
+			}
+			if blobs >= f.config.BlobFetchBatchSize && others >= f.config.LegacyFetchBatchSize {
 				return false // break in the for-each
 			}
 			bytes += uint64(meta.size)
@@ -950,11 +1517,15 @@ func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{},
 		if len(hashes) > 0 {
 			f.requests[peer] = &txRequest{hashes: hashes, time: f.clock.Now()}
 			txRequestOutMeter.Mark(int64(len(hashes)))
+			f.stats.requests.Add(uint64(len(hashes)))
+
+			f.trace(peer, "fetch %d txs", len(hashes))
 
 			go func(peer string, hashes []common.Hash) {
 				// Try to fetch the transactions, but in case of a request
 				// failure (e.g. peer disconnected), reschedule the hashes.
 				if err := f.fetchTxs(peer, hashes); err != nil {
+					f.trace(peer, "fetch failed: %v", err)
 					txRequestFailMeter.Mark(int64(len(hashes)))
 					f.Drop(peer)
 				}
@@ -967,6 +1538,36 @@ func (f *TxFetcher) scheduleFetches(timer *mclock.Timer, timeout chan struct{},
 	}
 }
 
+// requestDrop records the reason for disconnecting a misbehaving peer and asks
+// the owner of the fetcher to drop it.
+func (f *TxFetcher) requestDrop(peer string, reason string) {
+	if _, ok := f.violations[peer]; !ok {
+		f.violations[peer] = reason
+	}
+	f.penalize(peer, txPenaltyViolation)
+	f.dropPeer(peer)
+}
+
+// penalize reports a misbehaving peer to the penalty callback, if any is set.
+func (f *TxFetcher) penalize(peer string, score int) {
+	if peer != txLocalPeer && f.config.PeerPenaltyFn != nil {
+		f.config.PeerPenaltyFn(peer, score)
+	}
+}
+
+// queuedBytes returns the estimated memory contributed by the pending, not yet
+// delivered announcements of a peer, based on the announced transaction sizes.
+func (f *TxFetcher) queuedBytes(peer string) uint64 {
+	var bytes uint64
+	for _, meta := range f.waitslots[peer] {
+		bytes += uint64(meta.size)
+	}
+	for _, meta := range f.announces[peer] {
+		bytes += uint64(meta.size)
+	}
+	return bytes
+}
+
 // forEachPeer does a range loop over a map of peers in production, but during
 // testing it does a deterministic sorted random to allow reproducing issues.
 func (f *TxFetcher) forEachPeer(peers map[string]struct{}, do func(peer string)) {
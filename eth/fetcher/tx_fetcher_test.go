@@ -17,18 +17,34 @@
 package fetcher
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
 	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/testutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 )
 
 var (
@@ -65,6 +81,8 @@ type doWait struct {
 	step bool
 }
 type doDrop string
+type doPause struct{}
+type doResume struct{}
 type doFunc func()
 
 type isWaiting map[string][]announce
@@ -75,6 +93,10 @@ type isScheduled struct {
 	dangling map[string][]common.Hash
 }
 type isUnderpriced int
+type isAnnounced struct {
+	total   int
+	perPeer map[string]int
+}
 
 // txFetcherTest represents a test scenario that can be executed by the test
 // runner.
@@ -481,6 +503,158 @@ func TestTransactionFetcherSingletonRequesting(t *testing.T) {
 	})
 }
 
+// Tests that the announced transaction counts track the hashes through all the
+// stages until delivery.
+func TestTransactionFetcherAnnouncedCount(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcher(
+				func(common.Hash, byte) error { return nil },
+				func(txs []*types.Transaction) []error {
+					return make([]error, len(txs))
+				},
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+		},
+		steps: []interface{}{
+			isAnnounced{total: 0, perPeer: map[string]int{}},
+
+			// Announce overlapping transactions from two peers into the waitlist
+			doTxNotify{peer: "A", hashes: []common.Hash{testTxsHashes[0], testTxsHashes[1]}, types: []byte{testTxs[0].Type(), testTxs[1].Type()}, sizes: []uint32{uint32(testTxs[0].Size()), uint32(testTxs[1].Size())}},
+			doTxNotify{peer: "B", hashes: []common.Hash{testTxsHashes[1]}, types: []byte{testTxs[1].Type()}, sizes: []uint32{uint32(testTxs[1].Size())}},
+			isAnnounced{total: 2, perPeer: map[string]int{"A": 2, "B": 1}},
+
+			// Move them into fetching and ensure they are still counted
+			doWait{time: txArriveTimeout, step: true},
+			isAnnounced{total: 2, perPeer: map[string]int{"A": 2, "B": 1}},
+
+			// Deliver one of them and ensure it's not counted any more
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[0]}, direct: true},
+			isAnnounced{total: 1, perPeer: map[string]int{"A": 1, "B": 1}},
+		},
+	})
+}
+
+// Tests that pausing the fetcher keeps queueing up announcements without
+// dispatching any retrievals, and resuming schedules them all.
+func TestTransactionFetcherPauseResume(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcher(
+				func(common.Hash, byte) error { return nil },
+				nil,
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+		},
+		steps: []interface{}{
+			// Pause the fetcher and announce a few transactions, ensuring they
+			// get queued up but not retrieved
+			doPause{},
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x01}}, types: []byte{types.LegacyTxType}, sizes: []uint32{111}},
+			doTxNotify{peer: "B", hashes: []common.Hash{{0x02}}, types: []byte{types.LegacyTxType}, sizes: []uint32{222}},
+			doWait{time: txArriveTimeout, step: true},
+			isWaiting(nil),
+			isScheduled{
+				tracking: map[string][]announce{
+					"A": {{common.Hash{0x01}, types.LegacyTxType, 111}},
+					"B": {{common.Hash{0x02}, types.LegacyTxType, 222}},
+				},
+			},
+			// Pausing again is a noop, resuming should dispatch everything queued
+			doPause{},
+			doResume{},
+			isScheduled{
+				tracking: map[string][]announce{
+					"A": {{common.Hash{0x01}, types.LegacyTxType, 111}},
+					"B": {{common.Hash{0x02}, types.LegacyTxType, 222}},
+				},
+				fetching: map[string][]common.Hash{
+					"A": {{0x01}},
+					"B": {{0x02}},
+				},
+			},
+		},
+	})
+}
+
+// traceLines is an io.Writer collecting the written lines into a channel, so the
+// trace output can be consumed without racing the fetcher goroutines.
+type traceLines chan string
+
+func (w traceLines) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+// Tests that tracing a peer reports its fetches, deliveries, imports and drop,
+// but nothing about other peers or after the trace is cancelled.
+func TestTransactionFetcherTracePeer(t *testing.T) {
+	var (
+		lines  = make(traceLines, 16)
+		cancel func()
+	)
+	expect := func(want ...string) doFunc {
+		return func() {
+			for _, w := range want {
+				select {
+				case line := <-lines:
+					if !strings.HasSuffix(line, " A "+w+"\n") {
+						t.Errorf("trace line mismatch: have %q, want suffix %q", line, w)
+					}
+				default:
+					t.Errorf("missing trace line %q", w)
+				}
+			}
+			if len(lines) > 0 {
+				t.Errorf("unexpected trace line %q", <-lines)
+			}
+		}
+	}
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			f := NewTxFetcher(
+				func(common.Hash, byte) error { return nil },
+				func(txs []*types.Transaction) []error {
+					errs := make([]error, len(txs))
+					for i, tx := range txs {
+						if tx.Hash() == testTxsHashes[1] {
+							errs[i] = txpool.ErrUnderpriced
+						}
+					}
+					return errs
+				},
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+			cancel = f.TracePeer("A", lines)
+			return f
+		},
+		steps: []interface{}{
+			doTxNotify{peer: "A", hashes: []common.Hash{testTxsHashes[0]}, types: []byte{testTxs[0].Type()}, sizes: []uint32{uint32(testTxs[0].Size())}},
+			doTxNotify{peer: "B", hashes: []common.Hash{testTxsHashes[2]}, types: []byte{testTxs[2].Type()}, sizes: []uint32{uint32(testTxs[2].Size())}},
+			doWait{time: txArriveTimeout, step: true},
+			expect("fetch 1 txs"),
+
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[0], testTxs[1]}, direct: true},
+			doTxEnqueue{peer: "B", txs: []*types.Transaction{testTxs[2]}, direct: true},
+			expect(
+				"enqueue 2 txs (direct: true)",
+				fmt.Sprintf("accept %x", testTxsHashes[0]),
+				fmt.Sprintf("reject %x: %v", testTxsHashes[1], txpool.ErrUnderpriced),
+			),
+			doDrop("A"),
+			expect("drop: disconnected"),
+
+			// Cancel the trace, nothing should be reported anymore
+			doFunc(func() { cancel() }),
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[3]}, direct: false},
+			expect(),
+		},
+	})
+}
+
 // Tests that if a transaction retrieval fails, all the transactions get
 // instantly schedule back to someone else or the announcements dropped
 // if no alternate source is available.
@@ -1139,6 +1313,149 @@ func TestTransactionFetcherBandwidthLimiting(t *testing.T) {
 	})
 }
 
+// Tests that blob and non-blob transactions are batched into requests according
+// to their own, separately configured limits.
+func TestTransactionFetcherTypedBatchLimits(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcherWithConfig(
+				TxFetcherConfig{BlobFetchBatchSize: 2, LegacyFetchBatchSize: 3},
+				func(common.Hash, byte) error { return nil },
+				nil,
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+		},
+		steps: []interface{}{
+			// Announce a mix of small blob and legacy transactions, all fitting
+			// into the bandwidth limit
+			doTxNotify{peer: "A",
+				hashes: []common.Hash{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}, {0x06}, {0x07}},
+				types:  []byte{types.BlobTxType, types.BlobTxType, types.BlobTxType, types.LegacyTxType, types.LegacyTxType, types.LegacyTxType, types.LegacyTxType},
+				sizes:  []uint32{100, 100, 100, 100, 100, 100, 100},
+			},
+			doWait{time: txArriveTimeout, step: true},
+			isWaiting(nil),
+			isScheduled{
+				tracking: map[string][]announce{
+					"A": {
+						{common.Hash{0x01}, types.BlobTxType, 100},
+						{common.Hash{0x02}, types.BlobTxType, 100},
+						{common.Hash{0x03}, types.BlobTxType, 100},
+						{common.Hash{0x04}, types.LegacyTxType, 100},
+						{common.Hash{0x05}, types.LegacyTxType, 100},
+						{common.Hash{0x06}, types.LegacyTxType, 100},
+						{common.Hash{0x07}, types.LegacyTxType, 100},
+					},
+				},
+				fetching: map[string][]common.Hash{
+					"A": {{0x01}, {0x02}, {0x04}, {0x05}, {0x06}},
+				},
+			},
+		},
+	})
+}
+
+// Tests that announcements are dropped once the estimated memory contributed by
+// a peer's pending announcements would exceed the configured allowance.
+func TestTransactionFetcherPeerQueueBytesLimit(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcherWithConfig(
+				TxFetcherConfig{MaxPeerQueueBytes: 250},
+				func(common.Hash, byte) error { return nil },
+				nil,
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+		},
+		steps: []interface{}{
+			// Announce more than the allowance, the overflow should be dropped
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x01}, {0x02}, {0x03}}, types: []byte{types.LegacyTxType, types.LegacyTxType, types.LegacyTxType}, sizes: []uint32{100, 100, 100}},
+			isWaiting(map[string][]announce{
+				"A": {
+					{common.Hash{0x01}, types.LegacyTxType, 100},
+					{common.Hash{0x02}, types.LegacyTxType, 100},
+				},
+			}),
+			// Further announcements from the same peer should be rejected until
+			// the backlog drains, but other peers should be unaffected
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x04}}, types: []byte{types.LegacyTxType}, sizes: []uint32{100}},
+			doTxNotify{peer: "B", hashes: []common.Hash{{0x04}}, types: []byte{types.LegacyTxType}, sizes: []uint32{100}},
+			isWaiting(map[string][]announce{
+				"A": {
+					{common.Hash{0x01}, types.LegacyTxType, 100},
+					{common.Hash{0x02}, types.LegacyTxType, 100},
+				},
+				"B": {
+					{common.Hash{0x04}, types.LegacyTxType, 100},
+				},
+			}),
+			// Announcements still fitting into the remaining allowance are accepted
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x05}}, types: []byte{types.LegacyTxType}, sizes: []uint32{50}},
+			isWaiting(map[string][]announce{
+				"A": {
+					{common.Hash{0x01}, types.LegacyTxType, 100},
+					{common.Hash{0x02}, types.LegacyTxType, 100},
+					{common.Hash{0x05}, types.LegacyTxType, 50},
+				},
+				"B": {
+					{common.Hash{0x04}, types.LegacyTxType, 100},
+				},
+			}),
+			// Once the announcements are dropped, the peer may announce again
+			doDrop("A"),
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x06}}, types: []byte{types.LegacyTxType}, sizes: []uint32{200}},
+			isWaiting(map[string][]announce{
+				"A": {
+					{common.Hash{0x06}, types.LegacyTxType, 200},
+				},
+				"B": {
+					{common.Hash{0x04}, types.LegacyTxType, 100},
+				},
+			}),
+		},
+	})
+}
+
+// Tests that announcements of transaction types not accepted by the local pool
+// are ignored, so e.g. a pool without blob support never fetches blob txs.
+func TestTransactionFetcherAcceptTypes(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcherWithConfig(
+				TxFetcherConfig{Accept: 1<<types.LegacyTxType | 1<<types.DynamicFeeTxType},
+				func(common.Hash, byte) error { return nil },
+				nil,
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+		},
+		steps: []interface{}{
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x01}, {0x02}, {0x03}}, types: []byte{types.LegacyTxType, types.BlobTxType, types.DynamicFeeTxType}, sizes: []uint32{111, 222, 333}},
+			isWaiting(map[string][]announce{
+				"A": {
+					{common.Hash{0x01}, types.LegacyTxType, 111},
+					{common.Hash{0x03}, types.DynamicFeeTxType, 333},
+				},
+			}),
+			doWait{time: txArriveTimeout, step: true},
+			isWaiting(nil),
+			isScheduled{
+				tracking: map[string][]announce{
+					"A": {
+						{common.Hash{0x01}, types.LegacyTxType, 111},
+						{common.Hash{0x03}, types.DynamicFeeTxType, 333},
+					},
+				},
+				fetching: map[string][]common.Hash{
+					"A": {{0x01}, {0x03}},
+				},
+			},
+		},
+	})
+}
+
 // Tests that then number of transactions a peer is allowed to announce and/or
 // request at the same time is hard capped.
 func TestTransactionFetcherDoSProtection(t *testing.T) {
@@ -1908,67 +2225,1198 @@ func TestTransactionFetcherDropAlternates(t *testing.T) {
 	})
 }
 
-func TestTransactionFetcherWrongMetadata(t *testing.T) {
-	testTransactionFetcherParallel(t, txFetcherTest{
-		init: func() *TxFetcher {
-			return NewTxFetcher(
-				func(_ common.Hash, kind byte) error {
-					switch kind {
-					case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType, types.BlobTxType, types.SetCodeTxType:
-						return nil
-					}
-					return types.ErrTxTypeNotSupported
-				},
-				func(txs []*types.Transaction) []error {
-					return make([]error, len(txs))
-				},
-				func(string, []common.Hash) error { return nil },
-				nil,
-			)
-		},
-		steps: []interface{}{
-			doTxNotify{peer: "A", hashes: []common.Hash{{0x01}, {0x02}}, types: []byte{0xff, types.LegacyTxType}, sizes: []uint32{111, 222}},
-			isWaiting(map[string][]announce{
-				"A": {
-					{common.Hash{0x02}, types.LegacyTxType, 222},
-				},
-			}),
-		},
-	})
-}
+// Tests that the number of concurrent blob and non-blob transaction imports are
+// capped by their respectively configured queue workers.
+func TestTransactionFetcherQueueWorkers(t *testing.T) {
+	var (
+		active  atomic.Int32
+		maxBlob atomic.Int32
+		maxAll  atomic.Int32
+	)
+	track := func(peak *atomic.Int32, n int32) {
+		for {
+			if old := peak.Load(); n <= old || peak.CompareAndSwap(old, n) {
+				return
+			}
+		}
+	}
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{BlobQueueWorkers: 2, LegacyQueueWorkers: 4, MaxConcurrentKZGVerifications: 4},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			n := active.Add(1)
+			defer active.Add(-1)
 
-func testTransactionFetcherParallel(t *testing.T, tt txFetcherTest) {
-	t.Parallel()
-	testTransactionFetcher(t, tt)
+			if txs[0].Type() == types.BlobTxType {
+				track(&maxBlob, n)
+			}
+			track(&maxAll, n)
+			time.Sleep(20 * time.Millisecond)
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		blob := types.NewTx(&types.BlobTx{Nonce: uint64(i), BlobFeeCap: uint256.NewInt(1), BlobHashes: []common.Hash{{0x01}}})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Enqueue("A", []*types.Transaction{blob}, false)
+		}()
+	}
+	wg.Wait()
+	if peak := maxBlob.Load(); peak != 2 {
+		t.Errorf("blob validation concurrency mismatch: have %d, want 2", peak)
+	}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Enqueue("A", []*types.Transaction{testTxs[i%len(testTxs)]}, false)
+		}()
+	}
+	wg.Wait()
+	if peak := maxAll.Load(); peak != 4 {
+		t.Errorf("legacy import concurrency mismatch: have %d, want 4", peak)
+	}
 }
 
-func testTransactionFetcher(t *testing.T, tt txFetcherTest) {
-	// Create a fetcher and hook into it's simulated fields
-	clock := new(mclock.Simulated)
-	wait := make(chan struct{})
-
-	fetcher := tt.init()
-	fetcher.clock = clock
-	fetcher.step = wait
-	fetcher.rand = rand.New(rand.NewSource(0x3a29))
-
-	fetcher.Start()
-	defer fetcher.Stop()
-
-	defer func() { // drain the wait chan on exit
+// Tests that the number of concurrent KZG verifications is capped across all the
+// peers, even if more blob import workers are available, and that the waiting
+// deliveries don't spin up extra goroutines.
+func TestTransactionFetcherMaxConcurrentKZGVerifications(t *testing.T) {
+	const (
+		peers = 8
+		limit = 2
+	)
+	var (
+		active   atomic.Int32
+		peak     atomic.Int32
+		peakGors atomic.Int32
+		baseline = runtime.NumGoroutine()
+	)
+	track := func(counter *atomic.Int32, n int32) {
 		for {
-			select {
-			case <-wait:
-			default:
+			old := counter.Load()
+			if n <= old || counter.CompareAndSwap(old, n) {
 				return
 			}
 		}
-	}()
+	}
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{BlobQueueWorkers: peers, MaxConcurrentKZGVerifications: limit},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			n := active.Add(1)
+			defer active.Add(-1)
 
-	// Crunch through all the test steps and execute them
-	for i, step := range tt.steps {
-		// Process the original or expanded steps
-		switch step := step.(type) {
+			track(&peak, n)
+			track(&peakGors, int32(runtime.NumGoroutine()))
+			time.Sleep(time.Millisecond)
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < peers; i++ {
+		txs := make([]*types.Transaction, 32)
+		for j := range txs {
+			txs[j] = types.NewTx(&types.BlobTx{Nonce: uint64(i*len(txs) + j), BlobFeeCap: uint256.NewInt(1), BlobHashes: []common.Hash{{0x01}}})
+		}
+		wg.Add(1)
+		go func(peer string) {
+			defer wg.Done()
+			for _, tx := range txs {
+				f.Enqueue(peer, []*types.Transaction{tx}, true)
+			}
+		}(fmt.Sprintf("peer-%d", i))
+	}
+	wg.Wait()
+
+	if have := peak.Load(); have != limit {
+		t.Errorf("concurrent verification mismatch: have %d, want %d", have, limit)
+	}
+	// Besides the delivering peers, allow for the fetcher's own goroutines
+	if have, max := int(peakGors.Load()), baseline+peers+limit+4; have > max {
+		t.Errorf("goroutine count too high: have %d, want <= %d", have, max)
+	}
+}
+
+// Tests that legacy transactions are not held up by stalled blob validations,
+// even when delivered in the same batch.
+func TestTransactionFetcherQueueSeparation(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		legacy  = make(chan struct{}, 1)
+	)
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{BlobQueueWorkers: 1, LegacyQueueWorkers: 1},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			if txs[0].HasBlobs() {
+				<-release
+				for i := range errs {
+					errs[i] = txpool.ErrAlreadyKnown
+				}
+				return errs
+			}
+			legacy <- struct{}{}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	blob := types.NewTx(&types.BlobTx{BlobFeeCap: uint256.NewInt(1), BlobHashes: []common.Hash{{0x01}}})
+	go f.Enqueue("A", []*types.Transaction{blob}, false)
+	go f.Enqueue("B", []*types.Transaction{testTxs[0], blob, testTxs[1]}, false)
+
+	select {
+	case <-legacy:
+	case <-time.After(time.Second):
+		t.Fatalf("legacy import blocked behind blob validation")
+	}
+	close(release)
+
+	// Check that the mixed batch errors are mapped back to the right transactions
+	errs := f.importTxs(f.clock.Now(), []*types.Transaction{testTxs[2], blob, testTxs[3]})
+	<-legacy
+	if errs[0] != nil || !errors.Is(errs[1], txpool.ErrAlreadyKnown) || errs[2] != nil {
+		t.Errorf("mixed batch errors mismatch: %v", errs)
+	}
+}
+
+// Tests that a single announcement mixing blob and legacy transactions is tracked
+// as one batch, and that the delivered transactions are routed to the import
+// workers of their own kind.
+func TestTransactionFetcherMixedAnnouncementRouting(t *testing.T) {
+	var (
+		blob     = types.NewTx(&types.BlobTx{BlobFeeCap: uint256.NewInt(1), BlobHashes: []common.Hash{{0x01}}})
+		txs      = []*types.Transaction{testTxs[0], blob, testTxs[1]}
+		imported = make(chan []*types.Transaction, len(txs))
+	)
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{BlobQueueWorkers: 1, LegacyQueueWorkers: 1},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			imported <- txs
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		func(peer string) { t.Errorf("peer %s dropped", peer) },
+	)
+	f.Start()
+	defer f.Stop()
+
+	var (
+		kinds  = make([]byte, len(txs))
+		sizes  = make([]uint32, len(txs))
+		hashes = make([]common.Hash, len(txs))
+	)
+	for i, tx := range txs {
+		kinds[i], sizes[i], hashes[i] = tx.Type(), uint32(tx.Size()), tx.Hash()
+	}
+	if err := f.Notify("A", kinds, sizes, hashes); err != nil {
+		t.Fatalf("failed to notify mixed batch: %v", err)
+	}
+	if err := f.Enqueue("A", txs, true); err != nil {
+		t.Fatalf("failed to enqueue mixed batch: %v", err)
+	}
+	var blobs, legacies int
+	for blobs+legacies < len(txs) {
+		select {
+		case batch := <-imported:
+			for _, tx := range batch {
+				if tx.HasBlobs() != batch[0].HasBlobs() {
+					t.Fatalf("blob and legacy transactions imported together: %v", batch)
+				}
+				if tx.HasBlobs() {
+					blobs++
+				} else {
+					legacies++
+				}
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("transactions not imported: have %d blob, %d legacy", blobs, legacies)
+		}
+	}
+	if blobs != 1 || legacies != 2 {
+		t.Errorf("imported transaction mismatch: have %d blob, %d legacy, want 1, 2", blobs, legacies)
+	}
+}
+
+// Tests that callers can wait for the validation of specific transactions, and
+// time out on transactions never delivered, without leaking their waits.
+func TestTransactionFetcherWaitForValidation(t *testing.T) {
+	f := NewTxFetcher(
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i, tx := range txs {
+				if tx.Hash() == testTxs[1].Hash() {
+					errs[i] = txpool.ErrAlreadyKnown
+				}
+			}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	waited := make([]chan error, 2)
+	for i := range waited {
+		waited[i] = make(chan error, 1)
+		go func(hash common.Hash, res chan error) { res <- f.WaitForValidation(hash, 5*time.Second) }(testTxs[i].Hash(), waited[i])
+	}
+	for f.validations.pending.Load() != int32(len(waited)) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := f.Enqueue("A", []*types.Transaction{testTxs[0], testTxs[1]}, false); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	if err := <-waited[0]; err != nil {
+		t.Errorf("accepted transaction validation error: %v", err)
+	}
+	if err := <-waited[1]; !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Errorf("rejected transaction validation error mismatch: have %v, want %v", err, txpool.ErrAlreadyKnown)
+	}
+	if err := f.WaitForValidation(testTxs[2].Hash(), 10*time.Millisecond); !errors.Is(err, ErrValidationTimeout) {
+		t.Errorf("undelivered transaction error mismatch: have %v, want %v", err, ErrValidationTimeout)
+	}
+	if n := len(f.validations.waiters); n != 0 {
+		t.Errorf("waits leaked: %d", n)
+	}
+	if n := f.validations.pending.Load(); n != 0 {
+		t.Errorf("pending waits mismatch: have %d, want 0", n)
+	}
+}
+
+// Tests that a configured structured logger receives the fetcher's records,
+// including the per-transaction attributes of rejected deliveries.
+func TestTransactionFetcherStructuredLogger(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		config = DefaultTxFetcherConfig
+	)
+	config.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: log.LevelTrace}))
+
+	mock := &testutil.MockTxFetcherCallbacks{
+		AddTxsFunc: func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i := range txs {
+				errs[i] = txpool.ErrUnderpriced
+			}
+			return errs
+		},
+	}
+	f := NewTxFetcherWithConfig(config, mock.ValidateMeta, mock.AddTxs, mock.FetchTxs, mock.DropPeer)
+	f.Start()
+	defer f.Stop()
+
+	if err := f.Enqueue("A", []*types.Transaction{testTxs[0]}, false); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	var record struct {
+		Msg             string `json:"msg"`
+		Peer            string `json:"peer"`
+		TxHash          string `json:"txHash"`
+		TxType          string `json:"txType"`
+		ValidationError string `json:"validationError"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record %q: %v", buf.String(), err)
+	}
+	if record.Peer != "A" {
+		t.Errorf("peer mismatch: have %q, want %q", record.Peer, "A")
+	}
+	if record.TxHash != testTxs[0].Hash().Hex() {
+		t.Errorf("tx hash mismatch: have %s, want %s", record.TxHash, testTxs[0].Hash().Hex())
+	}
+	if want := types.TransactionTypeName(testTxs[0].Type()); record.TxType != want {
+		t.Errorf("tx type mismatch: have %q, want %q", record.TxType, want)
+	}
+	if record.ValidationError != txpool.ErrUnderpriced.Error() {
+		t.Errorf("validation error mismatch: have %q, want %q", record.ValidationError, txpool.ErrUnderpriced)
+	}
+}
+
+// Tests that the activity counters accumulate announcements and deliveries,
+// and that resetting them returns the accumulated values and zeroes them.
+func TestTransactionFetcherResetStats(t *testing.T) {
+	mock := &testutil.MockTxFetcherCallbacks{
+		AddTxsFunc: func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i, tx := range txs {
+				switch tx.Hash() {
+				case testTxs[0].Hash():
+					errs[i] = txpool.ErrAlreadyKnown
+				case testTxs[1].Hash():
+					errs[i] = txpool.ErrUnderpriced
+				case testTxs[2].Hash():
+					errs[i] = errors.New("invalid")
+				}
+			}
+			return errs
+		},
+	}
+	f := NewTxFetcher(mock.ValidateMeta, mock.AddTxs, mock.FetchTxs, mock.DropPeer)
+	f.Start()
+	defer f.Stop()
+
+	if err := f.Notify("A", []byte{testTxs[0].Type(), testTxs[1].Type()}, []uint32{uint32(testTxs[0].Size()), uint32(testTxs[1].Size())}, []common.Hash{testTxsHashes[0], testTxsHashes[1]}); err != nil {
+		t.Fatalf("failed to notify fetcher: %v", err)
+	}
+	if err := f.Enqueue("B", testTxs, false); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	want := TxFetcherStats{
+		Announces:   2,
+		Deliveries:  uint64(len(testTxs)),
+		Known:       1,
+		Underpriced: 1,
+		Rejected:    1,
+	}
+	if have := f.Stats(); have != want {
+		t.Errorf("stats mismatch: have %+v, want %+v", have, want)
+	}
+	if have := f.ResetStats(); have != want {
+		t.Errorf("reset stats mismatch: have %+v, want %+v", have, want)
+	}
+	if have := f.Stats(); have != (TxFetcherStats{}) {
+		t.Errorf("stats not zeroed after reset: %+v", have)
+	}
+	mock.AssertValidationCount(t, len(testTxs))
+	mock.AssertDropPeerNotCalled(t)
+}
+
+// Tests that the sidecars of rejected blob transactions are detached and recycled
+// if enabled, while those of accepted ones, or ones which might still be verified,
+// are kept.
+func TestTransactionFetcherRecycleBlobSidecars(t *testing.T) {
+	txs := make([]*types.Transaction, 3)
+	for i := range txs {
+		txs[i] = types.NewTx(&types.BlobTx{
+			Nonce:      uint64(i),
+			BlobFeeCap: uint256.NewInt(1),
+			Sidecar:    types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{{0x01}}, []kzg4844.Commitment{{0x02}}, []kzg4844.Proof{{0x03}}),
+		})
+	}
+	results := []error{nil, errors.New("invalid"), txpool.ErrKZGTimeout}
+
+	for _, recycle := range []bool{false, true} {
+		f := NewTxFetcherWithConfig(
+			TxFetcherConfig{RecycleBlobSidecars: recycle},
+			func(common.Hash, byte) error { return nil },
+			func(txs []*types.Transaction) []error {
+				errs := make([]error, len(txs))
+				for i, tx := range txs {
+					errs[i] = results[tx.Nonce()]
+				}
+				return errs
+			},
+			func(string, []common.Hash) error { return nil },
+			nil,
+		)
+		f.Start()
+
+		batch := make([]*types.Transaction, len(txs))
+		for i, tx := range txs {
+			batch[i] = tx.WithBlobTxSidecar(tx.BlobTxSidecar().Copy())
+		}
+		if err := f.Enqueue("A", batch, true); err != nil {
+			t.Fatalf("failed to enqueue transactions: %v", err)
+		}
+		f.Stop()
+
+		for i, tx := range batch {
+			if tx.Hash() != txs[i].Hash() {
+				t.Errorf("recycle %v, tx %d: hash changed", recycle, i)
+			}
+			recycled := tx.BlobTxSidecar() == nil
+			if want := recycle && i == 1; recycled != want {
+				t.Errorf("recycle %v, tx %d: sidecar recycled %v, want %v", recycle, i, recycled, want)
+			}
+		}
+	}
+}
+
+// Tests that force validating a transaction imports it right away if it was
+// delivered but is still waiting for an import slot, dropping its pending
+// announcement, and that bodies not pending import are refused.
+func TestTransactionFetcherForceValidate(t *testing.T) {
+	var (
+		blocked = make(chan struct{})
+		release = make(chan struct{})
+		imports = make(chan common.Hash, 4)
+	)
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{LegacyQueueWorkers: 1, MaxBodyCacheBytes: uint64(testTxs[0].Size() + testTxs[1].Size())},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			// Hold up the only import slot with the first delivery
+			if txs[0] == testTxs[1] {
+				close(blocked)
+				<-release
+			}
+			for _, tx := range txs {
+				imports <- tx.Hash()
+			}
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	// Deliver two transactions stuck behind the one blocking the import, the
+	// last of which goes over the cache limit, and get the first announced by
+	// another peer in the meantime
+	var wg sync.WaitGroup
+	enqueue := func(tx *types.Transaction) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Enqueue("A", []*types.Transaction{tx}, true)
+		}()
+	}
+	waitQueued := func(n int64) {
+		for f.importQueued.Load() != n {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	enqueue(testTxs[1])
+	<-blocked
+	enqueue(testTxs[0])
+	waitQueued(2)
+	enqueue(testTxs[2])
+	waitQueued(3)
+
+	if err := f.Notify("B", []byte{testTxs[0].Type()}, []uint32{uint32(testTxs[0].Size())}, []common.Hash{testTxsHashes[0]}); err != nil {
+		t.Fatalf("failed to notify fetcher: %v", err)
+	}
+	if f.ForceValidate(testTxsHashes[2]) {
+		t.Error("transaction over the cache limit force validated")
+	}
+	if !f.ForceValidate(testTxsHashes[0]) {
+		t.Fatal("pending transaction not force validated")
+	}
+	if hash := <-imports; hash != testTxsHashes[0] {
+		t.Fatalf("imported transaction mismatch: have %x, want %x", hash, testTxsHashes[0])
+	}
+	if total, _ := f.AnnouncedCount(); total != 0 {
+		t.Errorf("announcement not cleaned up: %d still tracked", total)
+	}
+	if f.ForceValidate(testTxsHashes[0]) {
+		t.Error("transaction force validated twice")
+	}
+	if f.ForceValidate(testTxsHashes[3]) {
+		t.Error("unknown transaction force validated")
+	}
+	// Once the deliveries are imported, their bodies should be released
+	close(release)
+	wg.Wait()
+
+	f.bodies.lock.Lock()
+	defer f.bodies.lock.Unlock()
+	if len(f.bodies.bodies) != 0 || f.bodies.size != 0 {
+		t.Errorf("bodies leaked: %d txs, %d bytes", len(f.bodies.bodies), f.bodies.size)
+	}
+}
+
+// Tests that force validation is refused if the body cache is disabled.
+func TestTransactionFetcherForceValidateDisabled(t *testing.T) {
+	mock := new(testutil.MockTxFetcherCallbacks)
+	f := NewTxFetcher(mock.ValidateMeta, mock.AddTxs, mock.FetchTxs, mock.DropPeer)
+	f.Start()
+	defer f.Stop()
+
+	if err := f.Enqueue("A", []*types.Transaction{testTxs[0]}, true); err != nil {
+		t.Fatalf("failed to enqueue transaction: %v", err)
+	}
+	if f.ForceValidate(testTxsHashes[0]) {
+		t.Error("transaction force validated with the cache disabled")
+	}
+	mock.AssertValidationCount(t, 1)
+}
+
+// Tests that the time transactions spend queueing for validation is recorded per
+// transaction kind.
+func TestTransactionFetcherLatencyHistogram(t *testing.T) {
+	clock := new(mclock.Simulated)
+	f := newTxFetcher(TxFetcherConfig{LegacyQueueWorkers: 1, BlobQueueWorkers: 1},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error { return make([]error, len(txs)) },
+		func(string, []common.Hash) error { return nil },
+		nil, clock, time.Now, nil,
+	)
+	go func() {
+		for {
+			select {
+			case <-f.cleanup:
+			case <-f.quit:
+				return
+			}
+		}
+	}()
+	defer f.Stop()
+
+	// Occupy the only legacy worker, so the delivery below has to queue up
+	f.legacyWorkers <- struct{}{}
+
+	done := make(chan error)
+	go func() { done <- f.Enqueue("A", []*types.Transaction{testTxs[0], testTxs[1]}, true) }()
+	for f.importQueued.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Run(time.Second)
+	<-f.legacyWorkers
+	if err := <-done; err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	if have := f.LatencyHistogram(types.LegacyTxType); !slices.Equal(have, []int64{int64(time.Second), int64(time.Second)}) {
+		t.Errorf("legacy queue times mismatch: have %v, want 2x %d", have, time.Second)
+	}
+	if have := f.LatencyHistogram(types.DynamicFeeTxType); len(have) != 2 {
+		t.Errorf("non-blob types not tracked together: have %v", have)
+	}
+	if have := f.LatencyHistogram(types.BlobTxType); len(have) != 0 {
+		t.Errorf("unexpected blob queue times: %v", have)
+	}
+	// Ensure the retained samples are capped
+	var samples txQueueSamples
+	for i := 0; i < txQueueTimeSamples+10; i++ {
+		samples.add(int64(i))
+	}
+	if len(samples.values) != txQueueTimeSamples || samples.values[0] != txQueueTimeSamples || samples.values[10] != 10 {
+		t.Errorf("ring buffer mismatch: len %d, first %d, eleventh %d", len(samples.values), samples.values[0], samples.values[10])
+	}
+}
+
+// Tests that idle notifications fire once all delivered transactions have been
+// validated, and right away if nothing is pending.
+func TestTransactionFetcherIdleNotify(t *testing.T) {
+	release := make(chan struct{})
+	f := NewTxFetcher(
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			<-release
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	select {
+	case <-f.IdleNotify():
+	default:
+		t.Fatal("idle fetcher not reported idle")
+	}
+	done := make(chan error)
+	go func() { done <- f.Enqueue("A", testTxs, true) }()
+	for f.importQueued.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	idle := f.IdleNotify()
+	select {
+	case <-idle:
+		t.Fatal("fetcher reported idle with pending validations")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-idle:
+	case <-time.After(time.Second):
+		t.Fatal("idle notification not delivered")
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+}
+
+// Tests that deliveries going over the memory allowance are written to the spill
+// buffer and imported once the in-flight imports drain.
+func TestTransactionFetcherSpill(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "spill")
+	imported := make(chan *types.Transaction, len(testTxs))
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{MaxMemoryBytes: 1, SpillDir: dir},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			for _, tx := range txs {
+				imported <- tx
+			}
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	if f.spill == nil {
+		t.Fatal("spill buffer not created")
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("spill directory created before first write: %v", err)
+	}
+	// Ensure the spill format retains blob sidecars
+	blobtx := types.NewTx(&types.BlobTx{
+		BlobFeeCap: uint256.NewInt(1),
+		Sidecar:    types.NewBlobTxSidecar(types.BlobSidecarVersion0, []kzg4844.Blob{{0x01}}, []kzg4844.Commitment{{0x02}}, []kzg4844.Proof{{0x03}}),
+	})
+	if !f.spill.push("A", true, []*types.Transaction{blobtx}) {
+		t.Fatal("failed to queue spilled transactions")
+	}
+	if err := f.spill.write(<-f.spill.queue); err != nil {
+		t.Fatalf("failed to spill transactions: %v", err)
+	}
+	peer, direct, txs, err := f.spill.pop()
+	if err != nil {
+		t.Fatalf("failed to load spilled transactions: %v", err)
+	}
+	if peer != "A" || !direct || len(txs) != 1 || !types.TransactionDeepEquals(txs[0], blobtx) {
+		t.Fatalf("spilled batch mismatch: have peer %s, direct %v, txs %v", peer, direct, txs)
+	}
+	f.spill.forget([]common.Hash{blobtx.Hash()})
+
+	// Deliver some transactions without running the spill loop, all of them
+	// should be deferred to the spill buffer due to the tiny allowance
+	go func() {
+		for {
+			select {
+			case <-f.cleanup:
+			case <-f.quit:
+				return
+			}
+		}
+	}()
+	defer f.Stop()
+
+	for _, tx := range testTxs {
+		if err := f.Enqueue("A", []*types.Transaction{tx}, false); err != nil {
+			t.Fatalf("failed to enqueue transaction: %v", err)
+		}
+	}
+	if n := len(f.spill.queue); n != len(testTxs) {
+		t.Fatalf("spilled batch count mismatch: have %d, want %d", n, len(testTxs))
+	}
+	select {
+	case tx := <-imported:
+		t.Fatalf("spilled transaction %x imported directly", tx.Hash())
+	default:
+	}
+	// Spilled transactions should not be fetched again if announced
+	for _, tx := range testTxs {
+		if !f.spill.has(tx.Hash()) {
+			t.Fatalf("spilled transaction %x not tracked", tx.Hash())
+		}
+	}
+	// Start the spill loop and ensure everything gets written and imported
+	go f.spillLoop()
+
+	for i := range testTxs {
+		select {
+		case tx := <-imported:
+			if tx.Hash() != testTxs[i].Hash() {
+				t.Errorf("import %d: transaction mismatch: have %x, want %x", i, tx.Hash(), testTxs[i].Hash())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("import %d: spilled transaction not imported", i)
+		}
+	}
+	<-f.IdleNotify()
+	if n := f.spill.len(); n != 0 {
+		t.Errorf("spill buffer not drained: %d batches left", n)
+	}
+	for _, tx := range testTxs {
+		if f.spill.has(tx.Hash()) {
+			t.Errorf("drained transaction %x still tracked", tx.Hash())
+		}
+	}
+}
+
+// Tests that deliveries going over both the memory allowance and the spill
+// limit are dropped and not reported as delivered, so they get fetched again.
+func TestTransactionFetcherSpillLimit(t *testing.T) {
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{MaxMemoryBytes: 1, SpillDir: t.TempDir(), MaxSpillBytes: testTxs[0].Size()},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			t.Errorf("transactions imported over the memory allowance")
+			return make([]error, len(txs))
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	defer f.Stop()
+
+	delivered := make(chan *txDelivery, 1)
+	go func() {
+		for {
+			select {
+			case delivery := <-f.cleanup:
+				delivered <- delivery
+			case <-f.quit:
+				return
+			}
+		}
+	}()
+	if err := f.Enqueue("A", testTxs[:2], true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	delivery := <-delivered
+	if len(delivery.hashes) != 0 {
+		t.Fatalf("dropped batch reported as delivered: %v", delivery.hashes)
+	}
+	if err := f.Enqueue("A", testTxs[:1], true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	delivery = <-delivered
+	if len(delivery.hashes) != 1 || delivery.hashes[0] != testTxs[0].Hash() {
+		t.Fatalf("spilled batch not reported as delivered: %v", delivery.hashes)
+	}
+	if err := f.Enqueue("A", testTxs[1:2], true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	delivery = <-delivered
+	if len(delivery.hashes) != 0 {
+		t.Fatalf("batch over the spill limit reported as delivered: %v", delivery.hashes)
+	}
+}
+
+// Tests that batches drained from the spill buffer have their results accounted
+// for the same way as directly imported ones.
+func TestTransactionFetcherSpillResults(t *testing.T) {
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{MaxMemoryBytes: 1, SpillDir: t.TempDir()},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i := range errs {
+				errs[i] = txpool.ErrUnderpriced
+			}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	go func() {
+		for {
+			select {
+			case <-f.cleanup:
+			case <-f.quit:
+				return
+			}
+		}
+	}()
+	defer f.Stop()
+
+	if err := f.Enqueue("A", testTxs, true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	go f.spillLoop()
+	<-f.IdleNotify()
+
+	for _, tx := range testTxs {
+		if !f.isKnownUnderpriced(tx.Hash()) {
+			t.Errorf("drained underpriced transaction %x not tracked", tx.Hash())
+		}
+	}
+	if n := f.stats.underpriced.Load(); n != uint64(len(testTxs)) {
+		t.Errorf("underpriced count mismatch: have %d, want %d", n, len(testTxs))
+	}
+}
+
+// Tests that the event log records every Enqueue call with the validation
+// results, and that the recorded log can be read back.
+func TestTransactionFetcherEventLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{EventLogPath: path},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i, tx := range txs {
+				if tx == testTxs[1] {
+					errs[i] = txpool.ErrUnderpriced
+				}
+			}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	if f.events == nil {
+		t.Fatal("event log not created")
+	}
+	f.Start()
+
+	if err := f.Enqueue("A", []*types.Transaction{testTxs[0], testTxs[1]}, true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	if err := f.Enqueue("B", []*types.Transaction{testTxs[2]}, false); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	f.Stop()
+	<-f.events.done
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open event log: %v", err)
+	}
+	defer file.Close()
+
+	var events []*TxEvent
+	if err := ReadTxEventLog(file, func(event *TxEvent) error {
+		events = append(events, event)
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to read event log: %v", err)
+	}
+	want := []*TxEvent{
+		{Peer: "A", Hashes: []common.Hash{testTxs[0].Hash(), testTxs[1].Hash()}, Direct: true, Errors: []string{"", txpool.ErrUnderpriced.Error()}},
+		{Peer: "B", Hashes: []common.Hash{testTxs[2].Hash()}, Direct: false, Errors: []string{""}},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("event count mismatch: have %d, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Time.IsZero() {
+			t.Errorf("event %d: missing timestamp", i)
+		}
+		event.Time = time.Time{}
+		if !reflect.DeepEqual(event, want[i]) {
+			t.Errorf("event %d: mismatch: have %+v, want %+v", i, event, want[i])
+		}
+	}
+}
+
+// Tests that the peer lifecycle hooks are invoked once per registration and
+// teardown, reporting the reason of fetcher requested drops.
+func TestTransactionFetcherPeerHooks(t *testing.T) {
+	events := make(chan string, 16)
+	expect := func(want ...string) doFunc {
+		return func() {
+			for _, event := range want {
+				select {
+				case have := <-events:
+					if have != event {
+						t.Errorf("hook event mismatch: have %q, want %q", have, event)
+					}
+				default:
+					t.Errorf("missing hook event %q", event)
+				}
+			}
+			select {
+			case have := <-events:
+				t.Errorf("unexpected hook event %q", have)
+			default:
+			}
+		}
+	}
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcherWithConfig(
+				TxFetcherConfig{
+					OnPeerRegistered: func(peer string) { events <- "register " + peer },
+					OnPeerDropped:    func(peer string, reason string) { events <- "drop " + peer + ": " + reason },
+				},
+				func(common.Hash, byte) error { return nil },
+				func(txs []*types.Transaction) []error {
+					return make([]error, len(txs))
+				},
+				func(string, []common.Hash) error { return nil },
+				func(string) {},
+			)
+		},
+		steps: []interface{}{
+			// Announce from two peers, one of them with bad metadata
+			doTxNotify{peer: "A", hashes: []common.Hash{testTxsHashes[0]}, types: []byte{testTxs[0].Type()}, sizes: []uint32{uint32(testTxs[0].Size())}},
+			doTxNotify{peer: "B", hashes: []common.Hash{testTxsHashes[0]}, types: []byte{1 + testTxs[0].Type()}, sizes: []uint32{uint32(testTxs[0].Size())}},
+			doTxNotify{peer: "A", hashes: []common.Hash{testTxsHashes[1]}, types: []byte{testTxs[1].Type()}, sizes: []uint32{uint32(testTxs[1].Size())}},
+			expect("register A", "register B"),
+
+			// Deliver the transaction, flagging the misbehaving peer
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[0]}},
+			expect(),
+
+			// Drop all the peers, including one never seen before
+			doDrop("B"),
+			doDrop("A"),
+			doDrop("C"),
+			expect("drop B: announced type mismatch", "drop A: disconnected"),
+
+			// Reannouncing after a drop should register the peer anew
+			doTxNotify{peer: "A", hashes: []common.Hash{testTxsHashes[2]}, types: []byte{testTxs[2].Type()}, sizes: []uint32{uint32(testTxs[2].Size())}},
+			expect("register A"),
+		},
+	})
+}
+
+// Tests that misbehaving peers are reported to the penalty callback.
+func TestTransactionFetcherPeerPenalties(t *testing.T) {
+	penalties := make(chan string, 16)
+	expect := func(want ...string) doFunc {
+		return func() {
+			for _, penalty := range want {
+				select {
+				case have := <-penalties:
+					if have != penalty {
+						t.Errorf("penalty mismatch: have %q, want %q", have, penalty)
+					}
+				default:
+					t.Errorf("missing penalty %q", penalty)
+				}
+			}
+			select {
+			case have := <-penalties:
+				t.Errorf("unexpected penalty %q", have)
+			default:
+			}
+		}
+	}
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcherWithConfig(
+				TxFetcherConfig{
+					PeerPenaltyFn: func(peer string, score int) { penalties <- fmt.Sprintf("%s %d", peer, score) },
+				},
+				func(common.Hash, byte) error { return nil },
+				func(txs []*types.Transaction) []error {
+					errs := make([]error, len(txs))
+					for i := range errs {
+						errs[i] = txpool.ErrAlreadyKnown
+					}
+					return errs
+				},
+				func(string, []common.Hash) error { return nil },
+				func(string) {},
+			)
+		},
+		steps: []interface{}{
+			// Request a transaction from a peer and let the request time out
+			doTxNotify{peer: "A", hashes: []common.Hash{testTxsHashes[0]}, types: []byte{testTxs[0].Type()}, sizes: []uint32{uint32(testTxs[0].Size())}},
+			doWait{time: txArriveTimeout, step: true},
+			expect(),
+			doWait{time: txFetchTimeout, step: true},
+			expect(fmt.Sprintf("A %d", txPenaltyTimeout)),
+
+			// Announce conflicting metadata and deliver the transaction, flagging
+			// the misbehaving peer
+			doTxNotify{peer: "B", hashes: []common.Hash{testTxsHashes[1]}, types: []byte{1 + testTxs[1].Type()}, sizes: []uint32{uint32(testTxs[1].Size())}},
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[1]}, direct: true},
+			expect(fmt.Sprintf("B %d", txPenaltyViolation)),
+
+			// Broadcast an already known transaction, which is not penalized as
+			// it is a natural race between peers
+			doTxEnqueue{peer: "C", txs: []*types.Transaction{testTxs[2]}},
+			expect(),
+		},
+	})
+}
+
+// Tests that deliveries rejected due to the blob pool being full do not count
+// as stale, so the delivering peer is not penalized for the local capacity.
+func TestTransactionFetcherBlobPoolFull(t *testing.T) {
+	var (
+		penalties = make(chan string, 16)
+		reject    atomic.Pointer[error]
+	)
+	f := NewTxFetcherWithConfig(
+		TxFetcherConfig{
+			PeerPenaltyFn: func(peer string, score int) { penalties <- fmt.Sprintf("%s %d", peer, score) },
+		},
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i := range errs {
+				errs[i] = *reject.Load()
+			}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	txs := make([]*types.Transaction, addTxsBatchSize)
+	for i := range txs {
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: uint64(i)})
+	}
+	// Deliver a batch rejected because the pool is full, expecting no penalty
+	full := fmt.Errorf("%w: evicted", txpool.ErrBlobPoolFull)
+	reject.Store(&full)
+	if err := f.Enqueue("A", txs, true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	select {
+	case have := <-penalties:
+		t.Errorf("unexpected penalty %q", have)
+	default:
+	}
+	// Deliver the same batch rejected for some other reason, expecting a penalty
+	invalid := errors.New("invalid")
+	reject.Store(&invalid)
+	if err := f.Enqueue("B", txs, true); err != nil {
+		t.Fatalf("failed to enqueue transactions: %v", err)
+	}
+	select {
+	case have := <-penalties:
+		if want := fmt.Sprintf("B %d", txPenaltyStale); have != want {
+			t.Errorf("penalty mismatch: have %q, want %q", have, want)
+		}
+	default:
+		t.Error("missing stale penalty")
+	}
+}
+
+// Tests that the health check reports the event loop as responsive only while
+// it is running and not stuck on some blocking operation.
+func TestTransactionFetcherHealthy(t *testing.T) {
+	fetcher := NewTxFetcher(
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error { return make([]error, len(txs)) },
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	if fetcher.Healthy(10 * time.Millisecond) {
+		t.Fatalf("fetcher healthy before start")
+	}
+	// Block the loop on the unconsumed test step channel after an announcement
+	fetcher.step = make(chan struct{})
+	fetcher.Start()
+
+	if !fetcher.Healthy(time.Second) {
+		t.Fatalf("running fetcher unhealthy")
+	}
+	if err := fetcher.Notify("A", []byte{types.LegacyTxType}, []uint32{111}, []common.Hash{{0x01}}); err != nil {
+		t.Fatalf("failed to notify fetcher: %v", err)
+	}
+	if fetcher.Healthy(10 * time.Millisecond) {
+		t.Fatalf("stuck fetcher healthy")
+	}
+	<-fetcher.step
+
+	if !fetcher.Healthy(time.Second) {
+		t.Fatalf("unblocked fetcher unhealthy")
+	}
+	fetcher.Stop()
+	if fetcher.Healthy(time.Second) {
+		t.Fatalf("stopped fetcher healthy")
+	}
+}
+
+// Tests that the validation latency tracker flags a slow 99th percentile over
+// the rolling window, rate limiting the warnings to one per window.
+func TestTransactionFetcherLatencyTracker(t *testing.T) {
+	var (
+		tracker   txLatencyTracker
+		threshold = time.Second
+		now       = mclock.AbsTime(0)
+	)
+	// A few fast transactions should not trigger any warnings
+	if p99, warn := tracker.record(now, 10*time.Millisecond, 99, threshold); warn || p99 != 10*time.Millisecond {
+		t.Fatalf("fast batch: have p99 %v (warn %v), want %v (warn false)", p99, warn, 10*time.Millisecond)
+	}
+	// A single slow transaction out of a hundred pushes the 99th percentile over
+	if p99, warn := tracker.record(now, 5*time.Second, 1, threshold); !warn || p99 != 5*time.Second {
+		t.Fatalf("slow batch: have p99 %v (warn %v), want %v (warn true)", p99, warn, 5*time.Second)
+	}
+	// Further slowness within the same window should not warn again
+	now += mclock.AbsTime(time.Second)
+	if _, warn := tracker.record(now, 5*time.Second, 1, threshold); warn {
+		t.Fatalf("repeated warning within window")
+	}
+	// Once the slow samples leave the window, fast ones should not warn
+	now += mclock.AbsTime(txLatencyWindow + time.Second)
+	if p99, warn := tracker.record(now, 10*time.Millisecond, 1, threshold); warn || p99 != 10*time.Millisecond {
+		t.Fatalf("recovered batch: have p99 %v (warn %v), want %v (warn false)", p99, warn, 10*time.Millisecond)
+	}
+	if len(tracker.samples) != 1 {
+		t.Fatalf("stale samples retained: have %d, want 1", len(tracker.samples))
+	}
+	// Renewed slowness after the window should warn again
+	if _, warn := tracker.record(now, 5*time.Second, 1, threshold); !warn {
+		t.Fatalf("missing warning after window")
+	}
+}
+
+func TestTransactionFetcherWrongMetadata(t *testing.T) {
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			return NewTxFetcher(
+				func(_ common.Hash, kind byte) error {
+					switch kind {
+					case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType, types.BlobTxType, types.SetCodeTxType:
+						return nil
+					}
+					return types.ErrTxTypeNotSupported
+				},
+				func(txs []*types.Transaction) []error {
+					return make([]error, len(txs))
+				},
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+		},
+		steps: []interface{}{
+			doTxNotify{peer: "A", hashes: []common.Hash{{0x01}, {0x02}}, types: []byte{0xff, types.LegacyTxType}, sizes: []uint32{111, 222}},
+			isWaiting(map[string][]announce{
+				"A": {
+					{common.Hash{0x02}, types.LegacyTxType, 222},
+				},
+			}),
+		},
+	})
+}
+
+func testTransactionFetcherParallel(t *testing.T, tt txFetcherTest) {
+	t.Parallel()
+	testTransactionFetcher(t, tt)
+}
+
+func testTransactionFetcher(t *testing.T, tt txFetcherTest) {
+	// Create a fetcher and hook into it's simulated fields
+	clock := new(mclock.Simulated)
+	wait := make(chan struct{})
+
+	fetcher := tt.init()
+	fetcher.clock = clock
+	fetcher.step = wait
+	fetcher.rand = rand.New(rand.NewSource(0x3a29))
+
+	fetcher.Start()
+	defer fetcher.Stop()
+
+	defer func() { // drain the wait chan on exit
+		for {
+			select {
+			case <-wait:
+			default:
+				return
+			}
+		}
+	}()
+
+	// Crunch through all the test steps and execute them
+	for i, step := range tt.steps {
+		// Process the original or expanded steps
+		switch step := step.(type) {
 		case doTxNotify:
 			if err := fetcher.Notify(step.peer, step.types, step.sizes, step.hashes); err != nil {
 				t.Errorf("step %d: %v", i, err)
@@ -1998,6 +3446,18 @@ func testTransactionFetcher(t *testing.T, tt txFetcherTest) {
 			}
 			<-wait // Fetcher needs to process this, wait until it's done
 
+		case doPause:
+			if err := fetcher.Pause(); err != nil {
+				t.Errorf("step %d: %v", i, err)
+			}
+			<-wait // Fetcher needs to process this, wait until it's done
+
+		case doResume:
+			if err := fetcher.Resume(); err != nil {
+				t.Errorf("step %d: %v", i, err)
+			}
+			<-wait // Fetcher needs to process this, wait until it's done
+
 		case doFunc:
 			step()
 
@@ -2217,6 +3677,15 @@ func testTransactionFetcher(t *testing.T, tt txFetcherTest) {
 				t.Errorf("step %d: underpriced set size mismatch: have %d, want %d", i, fetcher.underpriced.Len(), step)
 			}
 
+		case isAnnounced:
+			total, perPeer := fetcher.AnnouncedCount()
+			if total != step.total {
+				t.Errorf("step %d: announced count mismatch: have %d, want %d", i, total, step.total)
+			}
+			if !maps.Equal(perPeer, step.perPeer) {
+				t.Errorf("step %d: per-peer announced count mismatch: have %v, want %v", i, perPeer, step.perPeer)
+			}
+
 		default:
 			t.Fatalf("step %d: unknown step type %T", i, step)
 		}
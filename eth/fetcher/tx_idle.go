@@ -0,0 +1,71 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import "sync"
+
+// txIdleTracker counts the delivered transaction batches not yet validated by
+// the pool, notifying the waiters once all of them are done.
+type txIdleTracker struct {
+	pending int             // Batches waiting for or undergoing validation, or spilled
+	waiters []chan struct{} // Channels to close once the pending batches drain
+	lock    sync.Mutex
+}
+
+// add marks a new batch of transactions as pending validation.
+func (t *txIdleTracker) add() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.pending++
+}
+
+// done marks a batch as validated, waking up the waiters if it was the last one.
+func (t *txIdleTracker) done() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.pending--; t.pending == 0 {
+		for _, ch := range t.waiters {
+			close(ch)
+		}
+		t.waiters = nil
+	}
+}
+
+// wait returns a channel closed once there are no pending batches, which is
+// already closed if the fetcher is idle.
+func (t *txIdleTracker) wait() <-chan struct{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ch := make(chan struct{})
+	if t.pending == 0 {
+		close(ch)
+	} else {
+		t.waiters = append(t.waiters, ch)
+	}
+	return ch
+}
+
+// IdleNotify returns a channel that is closed once none of the transactions
+// delivered via Enqueue are waiting for or undergoing validation anymore,
+// including the batches held in the spill buffer. If the fetcher is already
+// idle, the returned channel is closed right away.
+func (f *TxFetcher) IdleNotify() <-chan struct{} {
+	return f.idle.wait()
+}
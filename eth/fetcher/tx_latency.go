@@ -0,0 +1,117 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/mclock"
+)
+
+// txLatencyWindow is the rolling window over which the validation latencies of
+// delivered transactions are aggregated. It is also the minimum interval between
+// two consecutive slow validation warnings.
+const txLatencyWindow = 10 * time.Second
+
+// txLatencySample is the validation latency of a batch of transactions imported
+// together, which thus all share the same latency.
+type txLatencySample struct {
+	time    mclock.AbsTime // Time the import of the batch finished
+	latency time.Duration  // Time between the enqueueing and the import finishing
+	count   int            // Number of transactions in the batch
+}
+
+// txLatencyTracker maintains the validation latencies of the transactions
+// imported within the rolling window.
+type txLatencyTracker struct {
+	samples  []txLatencySample // Samples within the window, oldest first
+	warned   bool              // Whether any warning was emitted yet
+	lastWarn mclock.AbsTime    // Time of the last warning emitted
+	lock     sync.Mutex
+}
+
+// record adds the validation latency of a batch of transactions to the window,
+// evicting the samples which fell out of it. If the 99th percentile latency is
+// above the threshold and no warning was emitted within the window, the method
+// returns the percentile and true, and the caller is expected to emit one.
+func (t *txLatencyTracker) record(now mclock.AbsTime, latency time.Duration, count int, threshold time.Duration) (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.samples = append(t.samples, txLatencySample{time: now, latency: latency, count: count})
+
+	var stale int
+	for stale < len(t.samples) && now.Sub(t.samples[stale].time) > txLatencyWindow {
+		stale++
+	}
+	t.samples = t.samples[stale:]
+
+	if t.warned && now.Sub(t.lastWarn) < txLatencyWindow {
+		return 0, false
+	}
+	p99 := t.percentile(0.99)
+	if p99 <= threshold {
+		return p99, false
+	}
+	t.warned, t.lastWarn = true, now
+	return p99, true
+}
+
+// percentile returns the given percentile of the transaction latencies within
+// the window, weighting each sample by the number of transactions in it.
+func (t *txLatencyTracker) percentile(p float64) time.Duration {
+	var total int
+	for _, sample := range t.samples {
+		total += sample.count
+	}
+	if total == 0 {
+		return 0
+	}
+	sorted := slices.Clone(t.samples)
+	slices.SortFunc(sorted, func(a, b txLatencySample) int {
+		return cmp.Compare(a.latency, b.latency)
+	})
+	var (
+		rank = int(p * float64(total))
+		seen int
+	)
+	for _, sample := range sorted {
+		seen += sample.count
+		if seen > rank {
+			return sample.latency
+		}
+	}
+	return sorted[len(sorted)-1].latency
+}
+
+// trackLatency records the validation latency of a batch of transactions that
+// got enqueued at the given time, warning if validation is falling behind.
+func (f *TxFetcher) trackLatency(start mclock.AbsTime, count int) {
+	threshold := f.config.ValidationLatencyWarnThreshold
+	if threshold == 0 {
+		return
+	}
+	now := f.clock.Now()
+	if p99, warn := f.latency.record(now, now.Sub(start), count, threshold); warn {
+		f.logger().Warn("Transaction validation falling behind", "p99", common.PrettyDuration(p99), "threshold", threshold,
+			"queued", f.importQueued.Load(), "peers", f.peerCount.Load())
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txQueueTimeSamples is the number of most recent queueing delays retained per
+// transaction kind for LatencyHistogram.
+const txQueueTimeSamples = 1024
+
+// txQueueSamples is a ring buffer of the most recent queueing delays.
+type txQueueSamples struct {
+	values []int64 // Delays in nanoseconds
+	next   int     // Index to overwrite once the buffer is full
+}
+
+// add inserts a delay into the ring buffer, evicting the oldest if full.
+func (s *txQueueSamples) add(delay int64) {
+	if len(s.values) < txQueueTimeSamples {
+		s.values = append(s.values, delay)
+		return
+	}
+	s.values[s.next] = delay
+	s.next = (s.next + 1) % txQueueTimeSamples
+}
+
+// txQueueTimes tracks the time delivered transactions spend between being
+// enqueued and being handed to the pool for validation, separately for blob and
+// non-blob transactions as they are imported through separate queues.
+type txQueueTimes struct {
+	legacy txQueueSamples
+	blob   txQueueSamples
+	lock   sync.Mutex
+}
+
+// record adds the queueing delay of a batch of transactions, one sample each.
+func (q *txQueueTimes) record(txs []*types.Transaction, delay time.Duration) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, tx := range txs {
+		if tx.Type() == types.BlobTxType {
+			txQueueBlobHist.Update(int64(delay))
+			q.blob.add(int64(delay))
+		} else {
+			txQueueLegacyHist.Update(int64(delay))
+			q.legacy.add(int64(delay))
+		}
+	}
+}
+
+// LatencyHistogram returns the most recent enqueue-to-validation delays, in
+// nanoseconds and in no particular order, of the transactions of the given type.
+// Blob transactions are tracked on their own, all other types together.
+func (f *TxFetcher) LatencyHistogram(txType byte) []int64 {
+	f.queueTimes.lock.Lock()
+	defer f.queueTimes.lock.Unlock()
+
+	if txType == types.BlobTxType {
+		return slices.Clone(f.queueTimes.blob.values)
+	}
+	return slices.Clone(f.queueTimes.legacy.values)
+}
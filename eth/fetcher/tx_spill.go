@@ -0,0 +1,265 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// txSpillQueueSize is the number of spilled batches that may wait in memory to
+// be written to disk. Batches arriving while the queue is full are dropped.
+const txSpillQueueSize = 16
+
+// txSpillBatch is the on-disk format of a spilled batch of transactions. Each
+// batch is stored RLP encoded in its own file, with the transactions in their
+// network encoding (i.e. including blob sidecars). The format is internal to
+// the fetcher and the files are deleted once drained or on shutdown.
+type txSpillBatch struct {
+	Peer   string
+	Direct bool
+	Txs    [][]byte
+}
+
+// txSpillWrite is a batch of transactions waiting to be written to disk.
+type txSpillWrite struct {
+	peer   string
+	direct bool
+	txs    []*types.Transaction
+	size   uint64
+}
+
+// txSpillFile is a batch of transactions written to disk.
+type txSpillFile struct {
+	path   string
+	size   uint64
+	hashes []common.Hash
+}
+
+// txSpill is a file based FIFO buffer of transaction batches which could not be
+// imported at the time of delivery without going over the memory allowance.
+//
+// Batches are accepted via push without blocking and are written to disk by the
+// fetcher's spill loop. The buffer accounts for the size of all queued and
+// written batches and refuses new ones beyond its limit.
+type txSpill struct {
+	parent string // Directory to create the spill directory in on first write
+	dir    string // Temporary directory holding the spilled batches
+	limit  uint64 // Maximum number of bytes of transactions to hold
+
+	queue  chan *txSpillWrite       // Batches waiting to be written to disk
+	files  []*txSpillFile           // Spilled batch files, oldest first
+	hashes map[common.Hash]struct{} // Transactions queued or written to disk
+	used   uint64                   // Size of the queued and written batches
+	seq    uint64                   // Sequence number for the next spilled batch
+	lock   sync.Mutex
+}
+
+// newTxSpill creates a spill buffer holding at most limit bytes of transactions
+// in a temporary directory within the given parent. The directory is only
+// created once the first batch is written.
+func newTxSpill(parent string, limit uint64) *txSpill {
+	return &txSpill{
+		parent: parent,
+		limit:  limit,
+		queue:  make(chan *txSpillWrite, txSpillQueueSize),
+		hashes: make(map[common.Hash]struct{}),
+	}
+}
+
+// push schedules a batch of transactions to be written to the end of the spill
+// buffer. It returns false if the batch would go over the size limit or if the
+// write queue is full, in which case the batch is not retained.
+func (s *txSpill) push(peer string, direct bool, txs []*types.Transaction) bool {
+	var size uint64
+	for _, tx := range txs {
+		size += tx.Size()
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.used+size > s.limit {
+		return false
+	}
+	select {
+	case s.queue <- &txSpillWrite{peer: peer, direct: direct, txs: txs, size: size}:
+	default:
+		return false
+	}
+	s.used += size
+	for _, tx := range txs {
+		s.hashes[tx.Hash()] = struct{}{}
+	}
+	return true
+}
+
+// write stores a queued batch on disk, creating the spill directory if needed.
+// If the write fails, the batch is discarded from the buffer.
+func (s *txSpill) write(batch *txSpillWrite) error {
+	hashes := make([]common.Hash, len(batch.txs))
+	for i, tx := range batch.txs {
+		hashes[i] = tx.Hash()
+	}
+	if err := s.store(batch, hashes); err != nil {
+		s.release(batch.size, hashes)
+		return err
+	}
+	return nil
+}
+
+// store encodes a batch and writes it into a new file in the spill directory.
+func (s *txSpill) store(batch *txSpillWrite, hashes []common.Hash) error {
+	enc := &txSpillBatch{
+		Peer:   batch.peer,
+		Direct: batch.direct,
+		Txs:    make([][]byte, len(batch.txs)),
+	}
+	for i, tx := range batch.txs {
+		blob, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		enc.Txs[i] = blob
+	}
+	blob, err := rlp.EncodeToBytes(enc)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.dir == "" {
+		if err := os.MkdirAll(s.parent, 0700); err != nil {
+			return err
+		}
+		dir, err := os.MkdirTemp(s.parent, "txfetcher-spill-")
+		if err != nil {
+			return err
+		}
+		s.dir = dir
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%016d.rlp", s.seq))
+	if err := os.WriteFile(path, blob, 0600); err != nil {
+		return err
+	}
+	s.seq++
+	s.files = append(s.files, &txSpillFile{path: path, size: batch.size, hashes: hashes})
+	return nil
+}
+
+// pop retrieves and deletes the oldest batch from the spill buffer. If there
+// are no spilled batches, nil transactions are returned.
+//
+// The transactions of the returned batch are still reported as spilled until
+// they are released via forget, so they are not fetched again while importing.
+func (s *txSpill) pop() (string, bool, []*types.Transaction, error) {
+	s.lock.Lock()
+	if len(s.files) == 0 {
+		s.lock.Unlock()
+		return "", false, nil, nil
+	}
+	file := s.files[0]
+	s.files = s.files[1:]
+	s.used -= file.size
+	s.lock.Unlock()
+
+	defer os.Remove(file.path)
+
+	peer, direct, txs, err := s.load(file.path)
+	if err != nil {
+		s.forget(file.hashes)
+		return "", false, nil, err
+	}
+	return peer, direct, txs, nil
+}
+
+// load reads and decodes a spilled batch file.
+func (s *txSpill) load(path string) (string, bool, []*types.Transaction, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, nil, err
+	}
+	var batch txSpillBatch
+	if err := rlp.DecodeBytes(blob, &batch); err != nil {
+		return "", false, nil, err
+	}
+	txs := make([]*types.Transaction, len(batch.Txs))
+	for i, enc := range batch.Txs {
+		txs[i] = new(types.Transaction)
+		if err := txs[i].UnmarshalBinary(enc); err != nil {
+			return "", false, nil, err
+		}
+	}
+	return batch.Peer, batch.Direct, txs, nil
+}
+
+// release discards the accounting of a batch that will not be written to disk.
+func (s *txSpill) release(size uint64, hashes []common.Hash) {
+	s.lock.Lock()
+	s.used -= size
+	s.lock.Unlock()
+
+	s.forget(hashes)
+}
+
+// forget stops reporting the given transactions as spilled.
+func (s *txSpill) forget(hashes []common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, hash := range hashes {
+		delete(s.hashes, hash)
+	}
+}
+
+// has reports whether a transaction is waiting in the spill buffer.
+func (s *txSpill) has(hash common.Hash) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.hashes[hash]
+	return ok
+}
+
+// len returns the number of batches currently spilled to disk.
+func (s *txSpill) len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.files)
+}
+
+// close deletes the spill buffer, discarding any batches not yet drained.
+func (s *txSpill) close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.files = nil
+	s.hashes = make(map[common.Hash]struct{})
+	s.used = 0
+
+	if s.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(s.dir)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import "sync/atomic"
+
+// TxFetcherStats is a snapshot of the activity counters of a transaction fetcher.
+// Unlike the metrics, which are process wide and monotonic, the counters belong
+// to a single fetcher and can be reset, allowing pollers to obtain deltas.
+type TxFetcherStats struct {
+	Announces   uint64 // Transaction hashes announced by peers
+	Requests    uint64 // Transactions requested from peers
+	Timeouts    uint64 // Transaction requests that timed out
+	Deliveries  uint64 // Transactions delivered, both directly and via broadcast
+	Known       uint64 // Delivered transactions already known by the pool
+	Underpriced uint64 // Delivered transactions rejected as underpriced
+	Rejected    uint64 // Delivered transactions rejected for any other reason
+	Spilled     uint64 // Delivered transactions deferred to the spill buffer
+}
+
+// txFetcherCounters are the live counters behind TxFetcherStats.
+type txFetcherCounters struct {
+	announces   atomic.Uint64
+	requests    atomic.Uint64
+	timeouts    atomic.Uint64
+	deliveries  atomic.Uint64
+	known       atomic.Uint64
+	underpriced atomic.Uint64
+	rejected    atomic.Uint64
+	spilled     atomic.Uint64
+}
+
+// snapshot returns the current value of the counters, zeroing them if reset is
+// requested.
+func (c *txFetcherCounters) snapshot(reset bool) TxFetcherStats {
+	read := func(counter *atomic.Uint64) uint64 {
+		if reset {
+			return counter.Swap(0)
+		}
+		return counter.Load()
+	}
+	return TxFetcherStats{
+		Announces:   read(&c.announces),
+		Requests:    read(&c.requests),
+		Timeouts:    read(&c.timeouts),
+		Deliveries:  read(&c.deliveries),
+		Known:       read(&c.known),
+		Underpriced: read(&c.underpriced),
+		Rejected:    read(&c.rejected),
+		Spilled:     read(&c.spilled),
+	}
+}
+
+// Stats returns the activity counters accumulated since the fetcher was created
+// or since the last ResetStats call.
+func (f *TxFetcher) Stats() TxFetcherStats {
+	return f.stats.snapshot(false)
+}
+
+// ResetStats zeroes the activity counters, returning their values prior to the
+// reset. Each counter is swapped atomically, so events happening concurrently
+// are counted in exactly one of two consecutive snapshots.
+func (f *TxFetcher) ResetStats() TxFetcherStats {
+	return f.stats.snapshot(true)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// txTracer is an active debug trace of a single peer's activity.
+type txTracer struct {
+	w    io.Writer
+	lock sync.Mutex // Serializes the writes of the concurrent event sources
+}
+
+// txTracers is the set of peers currently being traced.
+type txTracers struct {
+	active atomic.Int32 // Number of active tracers, to avoid locking when idle
+	peers  map[string]*txTracer
+	lock   sync.RWMutex
+}
+
+// TracePeer starts writing a timestamped line to w for every delivery, import
+// result, fetch request and drop involving the given peer, until the returned
+// cancel function is called. Only one trace may be active per peer, starting a
+// new one replaces the previous.
+//
+// The writes happen synchronously on the fetcher's goroutines, so w should not
+// block for long.
+func (f *TxFetcher) TracePeer(peer string, w io.Writer) (cancel func()) {
+	tracer := &txTracer{w: w}
+
+	f.tracers.lock.Lock()
+	defer f.tracers.lock.Unlock()
+
+	if f.tracers.peers == nil {
+		f.tracers.peers = make(map[string]*txTracer)
+	}
+	if _, ok := f.tracers.peers[peer]; !ok {
+		f.tracers.active.Add(1)
+	}
+	f.tracers.peers[peer] = tracer
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			f.tracers.lock.Lock()
+			defer f.tracers.lock.Unlock()
+
+			if f.tracers.peers[peer] == tracer {
+				delete(f.tracers.peers, peer)
+				f.tracers.active.Add(-1)
+			}
+		})
+	}
+}
+
+// trace writes an event line to the tracer of the given peer, if one is active.
+func (f *TxFetcher) trace(peer string, format string, args ...any) {
+	if f.tracers.active.Load() == 0 {
+		return
+	}
+	f.tracers.lock.RLock()
+	tracer := f.tracers.peers[peer]
+	f.tracers.lock.RUnlock()
+
+	if tracer == nil {
+		return
+	}
+	line := fmt.Sprintf("%s %s %s\n", f.realTime().Format(time.RFC3339Nano), peer, fmt.Sprintf(format, args...))
+
+	tracer.lock.Lock()
+	defer tracer.lock.Unlock()
+	io.WriteString(tracer.w, line)
+}
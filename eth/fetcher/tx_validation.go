@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrValidationTimeout is returned by WaitForValidation if the transaction was
+// not validated within the allowed time.
+var ErrValidationTimeout = errors.New("transaction validation timed out")
+
+// txValidationWaiter is the completion signal of a transaction's validation.
+type txValidationWaiter struct {
+	done chan struct{} // Closed when the validation finished
+	err  error         // Validation result, only valid after done is closed
+	refs int           // Number of callers waiting, guarded by the tracker lock
+}
+
+// txValidationTracker tracks the validation outcome of imported transactions
+// for the callers waiting on them. Results are only recorded for transactions
+// somebody is waiting on.
+type txValidationTracker struct {
+	waiters map[common.Hash]*txValidationWaiter // Pending waits, keyed by transaction hash
+	pending atomic.Int32                        // Number of pending waits, to skip locking if none
+	lock    sync.Mutex
+}
+
+// wait registers interest in a transaction's validation, returning the signal
+// closed once its result is known. Every call must be paired with a release.
+func (t *txValidationTracker) wait(hash common.Hash) *txValidationWaiter {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.waiters == nil {
+		t.waiters = make(map[common.Hash]*txValidationWaiter)
+	}
+	w, ok := t.waiters[hash]
+	if !ok {
+		w = &txValidationWaiter{done: make(chan struct{})}
+		t.waiters[hash] = w
+		t.pending.Add(1)
+	}
+	w.refs++
+	return w
+}
+
+// release drops a caller's interest in a transaction's validation, forgetting
+// about the transaction if nobody else is waiting on it.
+func (t *txValidationTracker) release(hash common.Hash, w *txValidationWaiter) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	w.refs--
+	if w.refs == 0 && t.waiters[hash] == w {
+		delete(t.waiters, hash)
+		t.pending.Add(-1)
+	}
+}
+
+// complete records the validation results of a batch of transactions, waking
+// up anyone waiting on them.
+func (t *txValidationTracker) complete(txs []*types.Transaction, errs []error) {
+	if t.pending.Load() == 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i, tx := range txs {
+		hash := tx.Hash()
+		w, ok := t.waiters[hash]
+		if !ok {
+			continue
+		}
+		if i < len(errs) {
+			w.err = errs[i]
+		}
+		close(w.done)
+		delete(t.waiters, hash)
+		t.pending.Add(-1)
+	}
+}
+
+// WaitForValidation blocks until the transaction with the given hash has been
+// passed to the pool, returning the pool's verdict on it. If the transaction is
+// not validated within the timeout (measured in real time), ErrValidationTimeout
+// is returned.
+//
+// Results are not retained, so the wait must start before the transaction is
+// delivered to the fetcher.
+func (f *TxFetcher) WaitForValidation(hash common.Hash, timeout time.Duration) error {
+	w := f.validations.wait(hash)
+	defer f.validations.release(hash, w)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-w.done:
+		return w.err
+	case <-timer.C:
+		return ErrValidationTimeout
+	case <-f.quit:
+		return errTerminated
+	}
+}
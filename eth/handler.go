@@ -109,6 +109,9 @@ type handlerConfig struct {
 	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
+
+	BlobValidationWorkers int     // Number of blob transaction batches to validate concurrently (0 = default)
+	TxServeRate           float64 // Pooled transaction requests answered per peer per second (0 = unlimited)
 }
 
 type handler struct {
@@ -125,6 +128,7 @@ type handler struct {
 	txFetcher      *fetcher.TxFetcher
 	peers          *peerSet
 	txBroadcastKey [16]byte
+	txServeRate    float64 // Pooled transaction requests served per peer per second
 
 	eventMux   *event.TypeMux
 	txsCh      chan core.NewTxsEvent
@@ -158,6 +162,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		peers:          newPeerSet(),
 		txBroadcastKey: newBroadcastChoiceKey(),
 		requiredBlocks: config.RequiredBlocks,
+		txServeRate:    config.TxServeRate,
 		quitSync:       make(chan struct{}),
 		handlerDoneCh:  make(chan struct{}),
 		handlerStartCh: make(chan struct{}),
@@ -190,7 +195,13 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return nil
 	}
 
-	h.txFetcher = fetcher.NewTxFetcher(validateMeta, addTxs, fetchTx, h.removePeer)
+	fetcherConfig := fetcher.DefaultTxFetcherConfig
+	fetcherConfig.PeerPenaltyFn = h.penalizePeer
+	if config.BlobValidationWorkers > 0 {
+		fetcherConfig.BlobQueueWorkers = config.BlobValidationWorkers
+		fetcherConfig.MaxConcurrentKZGVerifications = config.BlobValidationWorkers
+	}
+	h.txFetcher = fetcher.NewTxFetcherWithConfig(fetcherConfig, validateMeta, addTxs, fetchTx, h.removePeer)
 	return h, nil
 }
 
@@ -269,6 +280,7 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 		}
 	}
 	peer.Log().Debug("Ethereum peer connected", "name", peer.Name())
+	peer.SetPooledTransactionsServeRate(h.txServeRate)
 
 	// Register the peer locally
 	if err := h.peers.registerPeer(peer, snap); err != nil {
@@ -380,6 +392,24 @@ func (h *handler) removePeer(id string) {
 	}
 }
 
+// penalizePeer adds to the misbehaviour score of a peer, as reported by the
+// transaction fetcher. Penalized peers are the first to go when the dropper
+// makes room for new connections.
+func (h *handler) penalizePeer(id string, score int) {
+	if peer := h.peers.peer(id); peer != nil {
+		peer.penalty.Add(int64(score))
+	}
+}
+
+// peerPenalty returns the misbehaviour score accumulated by a peer, or zero if
+// the peer is not registered.
+func (h *handler) peerPenalty(id string) int64 {
+	if peer := h.peers.peer(id); peer != nil {
+		return peer.penalty.Load()
+	}
+	return 0
+}
+
 // unregisterPeer removes a peer from the downloader, fetchers and main peer set.
 func (h *handler) unregisterPeer(id string) {
 	// Create a custom logger to avoid printing the entire id
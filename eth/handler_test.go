@@ -309,6 +309,31 @@ func benchmarkBroadcastChoice(b *testing.B, npeers int) {
 	}
 }
 
+// Tests that the penalties reported by the transaction fetcher accumulate on the
+// registered peers, and are ignored for unknown ones.
+func TestPeerPenalty(t *testing.T) {
+	handler := newTestHandler(ethconfig.FullSync)
+	defer handler.close()
+
+	peer := eth.NewPeer(eth.ETH69, p2p.NewPeer(enode.ID{1}, "test", nil), nil, nil)
+	defer peer.Close()
+
+	if err := handler.handler.peers.registerPeer(peer, nil); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+	penalize := handler.handler.penalizePeer
+	penalize(peer.ID(), 2)
+	penalize(peer.ID(), 5)
+	penalize(enode.ID{2}.String(), 10)
+
+	if have := handler.handler.peerPenalty(peer.ID()); have != 7 {
+		t.Errorf("penalty mismatch: have %d, want 7", have)
+	}
+	if have := handler.handler.peerPenalty(enode.ID{2}.String()); have != 0 {
+		t.Errorf("unknown peer penalty mismatch: have %d, want 0", have)
+	}
+}
+
 func createTestPeers(rand *rand.Rand, n int) []*ethPeer {
 	peers := make([]*ethPeer, n)
 	for i := range peers {
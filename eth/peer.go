@@ -17,6 +17,8 @@
 package eth
 
 import (
+	"sync/atomic"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
@@ -39,6 +41,8 @@ type peerBlockRange struct {
 type ethPeer struct {
 	*eth.Peer
 	snapExt *snapPeer // Satellite `snap` connection
+
+	penalty atomic.Int64 // Misbehaviour score accumulated from the transaction fetcher
 }
 
 // info gathers and returns some `eth` protocol metadata known about a peer.
@@ -49,6 +49,11 @@ const (
 	// containing 200+ transactions nowadays, the practical limit will always
 	// be softResponseLimit.
 	maxReceiptsServe = 1024
+
+	// maxPooledTxsResponseSize is the hard cap on the accumulated size of the
+	// transactions in a PooledTransactions reply. It leaves some headroom below
+	// the protocol message size limit for the RLP framing and the request id.
+	maxPooledTxsResponseSize = maxMessageSize - 64
 )
 
 // Handler is a callback to invoke from an outside runner after the boilerplate
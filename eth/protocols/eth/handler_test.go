@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
@@ -695,3 +696,85 @@ func testGetPooledTransaction(t *testing.T, blobTx bool) {
 		t.Errorf("pooled transaction mismatch: %v", err)
 	}
 }
+
+// Tests that pooled transaction requests over the configured per-peer rate are
+// answered with an empty reply.
+func TestGetPooledTransactionsRateLimit(t *testing.T) {
+	backend := newTestBackendWithGenerator(0, true, true, nil)
+	defer backend.close()
+
+	peer, _ := newTestPeer("peer", ETH68, backend)
+	defer peer.close()
+
+	signer := types.NewCancunSigner(params.TestChainConfig.ChainID)
+	tx, err := types.SignTx(types.NewTransaction(0, testAddr, big.NewInt(10_000), params.TxGas, big.NewInt(1_000_000_000), nil), signer, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range backend.txpool.Add([]*types.Transaction{tx}, true) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Allow a single request, then no more for the duration of the test
+	peer.SetPooledTransactionsServeRate(0.001)
+
+	p2p.Send(peer.app, GetPooledTransactionsMsg, GetPooledTransactionsPacket{
+		RequestId:                    1,
+		GetPooledTransactionsRequest: []common.Hash{tx.Hash()},
+	})
+	if err := p2p.ExpectMsg(peer.app, PooledTransactionsMsg, PooledTransactionsPacket{
+		RequestId:                  1,
+		PooledTransactionsResponse: []*types.Transaction{tx},
+	}); err != nil {
+		t.Fatalf("first request not served: %v", err)
+	}
+	p2p.Send(peer.app, GetPooledTransactionsMsg, GetPooledTransactionsPacket{
+		RequestId:                    2,
+		GetPooledTransactionsRequest: []common.Hash{tx.Hash()},
+	})
+	if err := p2p.ExpectMsg(peer.app, PooledTransactionsMsg, PooledTransactionsPacket{RequestId: 2}); err != nil {
+		t.Errorf("rate limited request not answered empty: %v", err)
+	}
+}
+
+// rlpTxPool is a mock transaction pool serving pre-encoded blobs of arbitrary
+// sizes, used to exercise the reply size limits without building real txs.
+type rlpTxPool struct {
+	TxPool
+	blobs map[common.Hash][]byte
+}
+
+func (p *rlpTxPool) GetRLP(hash common.Hash) []byte { return p.blobs[hash] }
+
+// rlpTxPoolBackend is a mock backend only capable of serving the rlpTxPool.
+type rlpTxPoolBackend struct {
+	Backend
+	pool *rlpTxPool
+}
+
+func (b *rlpTxPoolBackend) TxPool() TxPool { return b.pool }
+
+// Tests that the pooled transaction reply never exceeds the protocol message
+// size cap, even if the soft limit was not yet reached.
+func TestGetPooledTransactionsResponseBudget(t *testing.T) {
+	pool := &rlpTxPool{blobs: map[common.Hash][]byte{
+		{0x01}: make([]byte, softResponseLimit-1),
+		{0x02}: make([]byte, maxMessageSize-softResponseLimit),
+		{0x03}: make([]byte, 1024),
+	}}
+	backend := &rlpTxPoolBackend{pool: pool}
+
+	// The first tx leaves the soft limit unreached, but the second one would
+	// overflow the hard limit, so it should be left out, keeping the request
+	// order for the rest.
+	hashes, txs := answerGetPooledTransactions(backend, GetPooledTransactionsRequest{{0x01}, {0x02}, {0x03}})
+	if want := []common.Hash{{0x01}, {0x03}}; !slices.Equal(hashes, want) || len(txs) != len(want) {
+		t.Fatalf("unexpected reply: have %v, want %v", hashes, want)
+	}
+	// A tx unknown to the pool should be skipped without consuming budget.
+	hashes, _ = answerGetPooledTransactions(backend, GetPooledTransactionsRequest{{0xff}, {0x03}, {0x01}, {0x03}})
+	if want := []common.Hash{{0x03}, {0x01}}; !slices.Equal(hashes, want) {
+		t.Fatalf("unexpected reply: have %v, want %v", hashes, want)
+	}
+}
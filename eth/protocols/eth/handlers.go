@@ -457,19 +457,23 @@ func handleGetPooledTransactions(backend Backend, msg Decoder, peer *Peer) error
 	if err := msg.Decode(&query); err != nil {
 		return err
 	}
+	// Answer with an empty reply if the peer is asking for transactions too
+	// often, so its request doesn't hang until timing out
+	if peer.txServe != nil && !peer.txServe.Allow() {
+		peer.Log().Debug("Pooled transactions request over rate limit", "reqid", query.RequestId)
+		return peer.ReplyPooledTransactionsRLP(query.RequestId, nil, nil)
+	}
 	hashes, txs := answerGetPooledTransactions(backend, query.GetPooledTransactionsRequest)
 	return peer.ReplyPooledTransactionsRLP(query.RequestId, hashes, txs)
 }
 
 func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsRequest) ([]common.Hash, []rlp.RawValue) {
-	// Gather transactions until the fetch or network limits is reached
-	var (
-		bytes  int
-		hashes []common.Hash
-		txs    []rlp.RawValue
-	)
+	// Gather transactions until the fetch or network limits is reached. Items
+	// not fitting under the protocol limit are left out, the requester will
+	// reschedule anything missing from the response.
+	builder := NewPooledTxsMessageBuilder(maxPooledTxsResponseSize)
 	for _, hash := range query {
-		if bytes >= softResponseLimit {
+		if builder.Size() >= softResponseLimit {
 			break
 		}
 		// Retrieve the requested transaction, skipping if unknown to us
@@ -477,10 +481,9 @@ func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsReq
 		if len(encoded) == 0 {
 			continue
 		}
-		hashes = append(hashes, hash)
-		txs = append(txs, encoded)
-		bytes += len(encoded)
+		builder.Add(hash, encoded)
 	}
+	hashes, txs, _ := builder.Build()
 	return hashes, txs
 }
 
@@ -17,6 +17,7 @@
 package eth
 
 import (
+	"math"
 	"math/rand"
 	"sync/atomic"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -54,6 +56,7 @@ type Peer struct {
 	knownTxs    *knownCache        // Set of transaction hashes known to be known by this peer
 	txBroadcast chan []common.Hash // Channel used to queue transaction propagation requests
 	txAnnounce  chan []common.Hash // Channel used to queue transaction announcement requests
+	txServe     *rate.Limiter      // Limiter for serving pooled transaction requests (nil = unlimited)
 
 	reqDispatch chan *request  // Dispatch channel to send requests and track then until fulfillment
 	reqCancel   chan *cancel   // Dispatch channel to cancel pending requests and untrack them
@@ -87,6 +90,18 @@ func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter, txpool TxPool) *Pe
 	return peer
 }
 
+// SetPooledTransactionsServeRate limits the number of pooled transaction requests
+// served to the peer to the given rate per second; requests over it are dropped.
+// A non-positive rate disables the limit. It must be called before the message
+// handler of the peer is started.
+func (p *Peer) SetPooledTransactionsServeRate(limit float64) {
+	if limit <= 0 {
+		p.txServe = nil
+		return
+	}
+	p.txServe = rate.NewLimiter(rate.Limit(limit), int(math.Ceil(limit)))
+}
+
 // Close signals the broadcast goroutine to terminate. Only ever call this if
 // you created the peer yourself via NewPeer. Otherwise let whoever created it
 // clean it up!
@@ -0,0 +1,125 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// PooledTxsMessageBuilder assembles a PooledTransactions reply by greedily
+// packing the added transactions up to a byte budget.
+//
+// The protocol requires the reply to follow the order of the request, with the
+// server only allowed to leave items out. Packing thus keeps the insertion order,
+// but a transaction not fitting the remaining budget is skipped instead of ending
+// the reply, so a large blob does not prevent smaller transactions requested
+// after it from filling up the rest of the message.
+type PooledTxsMessageBuilder struct {
+	budget  int // Maximum accumulated size of the packed transactions
+	used    int // Accumulated size of the packed transactions
+	skipped int // Number of transactions left out for not fitting the budget
+
+	hashes []common.Hash  // Hashes of the packed transactions, in insertion order
+	txs    []rlp.RawValue // Encodings of the packed transactions, in insertion order
+}
+
+// NewPooledTxsMessageBuilder creates a reply builder packing transactions up to
+// the byte budget.
+func NewPooledTxsMessageBuilder(budget int) *PooledTxsMessageBuilder {
+	return &PooledTxsMessageBuilder{budget: budget}
+}
+
+// Add packs a network encoded transaction into the reply if it fits into the
+// remaining budget, returning whether it was included.
+func (b *PooledTxsMessageBuilder) Add(hash common.Hash, encoded rlp.RawValue) bool {
+	if b.used+len(encoded) > b.budget {
+		b.skipped++
+		return false
+	}
+	b.used += len(encoded)
+	b.hashes = append(b.hashes, hash)
+	b.txs = append(b.txs, encoded)
+	return true
+}
+
+// Size returns the accumulated size of the packed transactions.
+func (b *PooledTxsMessageBuilder) Size() int {
+	return b.used
+}
+
+// Build returns the hashes and encodings of the packed transactions, along with
+// the number of transactions left out of the reply.
+func (b *PooledTxsMessageBuilder) Build() ([]common.Hash, []rlp.RawValue, int) {
+	return b.hashes, b.txs, b.skipped
+}
+
+// WritePooledTransactionsMsg streams the RLP list of a PooledTransactions reply
+// into w, encoding the transactions one by one instead of assembling the entire
+// message in memory first. Transactions are included in order until the next one
+// would push the total encoded size over maxBytes.
+//
+// Only the transaction list is written, wrapping it with the request id is up to
+// the caller. The number of transactions included in the list is returned.
+func WritePooledTransactionsMsg(w io.Writer, txs []*types.Transaction, maxBytes int) (int, error) {
+	var (
+		count int
+		size  uint64
+	)
+	for _, tx := range txs {
+		txsize := pooledTxSize(tx)
+		if size+txsize > uint64(maxBytes) {
+			break
+		}
+		size += txsize
+		count++
+	}
+	if _, err := w.Write(listHeader(size)); err != nil {
+		return 0, err
+	}
+	for i, tx := range txs[:count] {
+		if err := tx.EncodeRLP(w); err != nil {
+			return i, err
+		}
+	}
+	return count, nil
+}
+
+// pooledTxSize returns the size of a transaction's network encoding as an item
+// of an RLP list. Typed transactions are wrapped into an RLP string, the header
+// of which has the same length as a list header for the same content size.
+func pooledTxSize(tx *types.Transaction) uint64 {
+	if tx.Type() == types.LegacyTxType {
+		return tx.Size()
+	}
+	return rlp.ListSize(tx.Size())
+}
+
+// listHeader returns the RLP header of a list with the given content size.
+func listHeader(size uint64) []byte {
+	if size < 56 {
+		return []byte{0xc0 + byte(size)}
+	}
+	enc := binary.BigEndian.AppendUint64(nil, size)
+	enc = enc[bits.LeadingZeros64(size)/8:]
+	return append([]byte{0xf7 + byte(len(enc))}, enc...)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the pooled transaction reply builder packs transactions greedily
+// in request order, reporting the ones left out.
+func TestPooledTxsMessageBuilder(t *testing.T) {
+	// Blobs weigh 100 bytes, others 10 bytes each
+	var (
+		blob1 = common.Hash{0xb1}
+		blob2 = common.Hash{0xb2}
+		tx1   = common.Hash{0x01}
+		tx2   = common.Hash{0x02}
+		tx3   = common.Hash{0x03}
+	)
+	tests := []struct {
+		budget  int
+		hashes  []common.Hash
+		skipped int
+	}{
+		// Everything fits, the request order is retained
+		{1000, []common.Hash{blob1, tx1, tx2, blob2, tx3}, 0},
+
+		// A single blob fits, the rest of the budget is filled up greedily
+		{125, []common.Hash{blob1, tx1, tx2}, 2},
+
+		// No blob fits, but the small transactions still do
+		{50, []common.Hash{tx1, tx2, tx3}, 2},
+		{0, nil, 5},
+	}
+	for i, tt := range tests {
+		builder := NewPooledTxsMessageBuilder(tt.budget)
+		builder.Add(blob1, make(rlp.RawValue, 100))
+		builder.Add(tx1, make(rlp.RawValue, 10))
+		builder.Add(tx2, make(rlp.RawValue, 10))
+		builder.Add(blob2, make(rlp.RawValue, 100))
+		builder.Add(tx3, make(rlp.RawValue, 10))
+
+		hashes, txs, skipped := builder.Build()
+		if !slices.Equal(hashes, tt.hashes) {
+			t.Errorf("test %d: packed hashes mismatch: have %v, want %v", i, hashes, tt.hashes)
+		}
+		if len(txs) != len(hashes) {
+			t.Errorf("test %d: packed tx count mismatch: have %d, want %d", i, len(txs), len(hashes))
+		}
+		var size int
+		for _, tx := range txs {
+			size += len(tx)
+		}
+		if size > tt.budget || size != builder.Size() {
+			t.Errorf("test %d: packed size %d (reported %d) over budget %d", i, size, builder.Size(), tt.budget)
+		}
+		if skipped != tt.skipped {
+			t.Errorf("test %d: skipped count mismatch: have %d, want %d", i, skipped, tt.skipped)
+		}
+	}
+}
+
+// Tests that the streamed PooledTransactions list matches the one produced by
+// the regular RLP encoder, and that it is cut off at the byte limit.
+func TestWritePooledTransactionsMsg(t *testing.T) {
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 0, To: &common.Address{}}),
+		types.NewTx(&types.DynamicFeeTx{Nonce: 1, Data: make([]byte, 100)}),
+		types.NewTx(&types.AccessListTx{Nonce: 2, Data: make([]byte, 1000)}),
+		types.NewTx(&types.LegacyTx{Nonce: 3, Data: make([]byte, 10)}),
+	}
+	var sizes []int
+	for _, tx := range txs {
+		enc, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			t.Fatalf("failed to encode transaction: %v", err)
+		}
+		sizes = append(sizes, len(enc))
+	}
+	tests := []struct {
+		maxBytes int
+		count    int
+	}{
+		{0, 0},
+		{sizes[0] - 1, 0},
+		{sizes[0], 1},
+		{sizes[0] + sizes[1], 2},
+		{sizes[0] + sizes[1] + sizes[2] - 1, 2},
+		{sizes[0] + sizes[1] + sizes[2] + sizes[3], 4},
+		{1 << 20, 4},
+	}
+	for i, tt := range tests {
+		var buf bytes.Buffer
+		count, err := WritePooledTransactionsMsg(&buf, txs, tt.maxBytes)
+		if err != nil {
+			t.Fatalf("test %d: failed to write message: %v", i, err)
+		}
+		if count != tt.count {
+			t.Errorf("test %d: transaction count mismatch: have %d, want %d", i, count, tt.count)
+		}
+		want, err := rlp.EncodeToBytes(PooledTransactionsResponse(txs[:tt.count]))
+		if err != nil {
+			t.Fatalf("test %d: failed to encode reference message: %v", i, err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("test %d: encoding mismatch: have %x, want %x", i, buf.Bytes(), want)
+		}
+	}
+}
@@ -158,6 +158,10 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'txFetcherHealthy',
+			call: 'admin_txFetcherHealthy'
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -212,6 +216,11 @@ web3._extend({
 			call: 'debug_getRawTransaction',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'validateTransaction',
+			call: 'debug_validateTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'setHead',
 			call: 'debug_setHead',
@@ -0,0 +1,79 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import "math/big"
+
+// ActiveBlobConfig returns the blob config of the latest fork active at the
+// given time which defines one, or nil if blob transactions are not enabled.
+func (c *ChainConfig) ActiveBlobConfig(time uint64) *BlobConfig {
+	if c.BlobScheduleConfig == nil {
+		return nil
+	}
+	var (
+		london = c.LondonBlock
+		s      = c.BlobScheduleConfig
+	)
+	switch {
+	case c.IsBPO5(london, time) && s.BPO5 != nil:
+		return s.BPO5
+	case c.IsBPO4(london, time) && s.BPO4 != nil:
+		return s.BPO4
+	case c.IsBPO3(london, time) && s.BPO3 != nil:
+		return s.BPO3
+	case c.IsBPO2(london, time) && s.BPO2 != nil:
+		return s.BPO2
+	case c.IsBPO1(london, time) && s.BPO1 != nil:
+		return s.BPO1
+	case c.IsOsaka(london, time) && s.Osaka != nil:
+		return s.Osaka
+	case c.IsPrague(london, time) && s.Prague != nil:
+		return s.Prague
+	case c.IsCancun(london, time) && s.Cancun != nil:
+		return s.Cancun
+	default:
+		return nil
+	}
+}
+
+// BlobGasPrice calculates the blob base fee from the excess blob gas, using the
+// update fraction of the fork active at the given time. It returns nil if blob
+// transactions are not enabled at that time.
+func BlobGasPrice(excessBlobGas uint64, config *ChainConfig, time uint64) *big.Int {
+	bc := config.ActiveBlobConfig(time)
+	if bc == nil {
+		return nil
+	}
+	return fakeExponential(big.NewInt(BlobTxMinBlobGasprice), new(big.Int).SetUint64(excessBlobGas), new(big.Int).SetUint64(bc.UpdateFraction))
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using
+// Taylor expansion.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+	return output.Div(output, denominator)
+}
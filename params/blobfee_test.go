@@ -0,0 +1,121 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestBlobGasPrice(t *testing.T) {
+	config := *MergedTestChainConfig
+	config.CancunTime = newUint64(10)
+	config.PragueTime = newUint64(20)
+	config.OsakaTime = nil
+	config.BPO1Time = nil
+	config.BPO2Time = nil
+
+	tests := []struct {
+		time   uint64
+		excess uint64
+		want   int64
+	}{
+		{0, 0, -1},                  // pre-Cancun, no blob fee
+		{10, 0, 1},                  // minimum blob fee
+		{10, 10 * 1024 * 1024, 23},  // Cancun update fraction
+		{20, 10 * 1024 * 1024, 8},   // Prague update fraction
+		{1000, 10 * 1024 * 1024, 8}, // latest configured fork
+	}
+	for i, tt := range tests {
+		have := BlobGasPrice(tt.excess, &config, tt.time)
+		if tt.want < 0 {
+			if have != nil {
+				t.Errorf("test %d: blob fee mismatch: have %v, want nil", i, have)
+			}
+			continue
+		}
+		if have == nil || have.Int64() != tt.want {
+			t.Errorf("test %d: blob fee mismatch: have %v, want %d", i, have, tt.want)
+		}
+	}
+}
+
+func TestFakeExponential(t *testing.T) {
+	tests := []struct {
+		factor      int64
+		numerator   int64
+		denominator int64
+		want        int64
+	}{
+		// When numerator == 0 the return value should always equal the value of factor
+		{1, 0, 1, 1},
+		{38493, 0, 1000, 38493},
+		{0, 1234, 2345, 0}, // should be 0
+		{1, 2, 1, 6},       // approximate 7.389
+		{1, 4, 2, 6},
+		{1, 3, 1, 16}, // approximate 20.09
+		{1, 6, 2, 18},
+		{1, 4, 1, 49}, // approximate 54.60
+		{1, 8, 2, 50},
+		{10, 8, 2, 542}, // approximate 540.598
+		{11, 8, 2, 596}, // approximate 600.58
+		{1, 5, 1, 136},  // approximate 148.4
+		{1, 5, 2, 11},   // approximate 12.18
+		{2, 5, 2, 23},   // approximate 24.36
+		{1, 50000000, 2225652, 5709098764},
+	}
+	for i, tt := range tests {
+		f, n, d := big.NewInt(tt.factor), big.NewInt(tt.numerator), big.NewInt(tt.denominator)
+		original := fmt.Sprintf("%d %d %d", f, n, d)
+		have := fakeExponential(f, n, d)
+		if have.Int64() != tt.want {
+			t.Errorf("test %d: fake exponential mismatch: have %v want %v", i, have, tt.want)
+		}
+		later := fmt.Sprintf("%d %d %d", f, n, d)
+		if original != later {
+			t.Errorf("test %d: fake exponential modified arguments: have\n%v\nwant\n%v", i, later, original)
+		}
+	}
+}
+
+func TestBlobConfigBlobGas(t *testing.T) {
+	tests := []struct {
+		config *BlobConfig
+		target uint64
+		max    uint64
+	}{
+		{config: &BlobConfig{}, target: 0, max: 0},
+		{config: DefaultCancunBlobConfig, target: 3 * BlobTxBlobGasPerBlob, max: 6 * BlobTxBlobGasPerBlob},
+		{config: DefaultPragueBlobConfig, target: 6 * BlobTxBlobGasPerBlob, max: 9 * BlobTxBlobGasPerBlob},
+		{config: &BlobConfig{Target: -1, Max: -1}, target: 0, max: 0},
+	}
+	for i, tt := range tests {
+		if have := tt.config.BlobGasTarget(); have != tt.target {
+			t.Errorf("test %d: blob gas target mismatch: have %d, want %d", i, have, tt.target)
+		}
+		if have := tt.config.BlobGasMax(); have != tt.max {
+			t.Errorf("test %d: blob gas max mismatch: have %d, want %d", i, have, tt.max)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("nil blob config did not panic")
+		}
+	}()
+	(*BlobConfig)(nil).BlobGasTarget()
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/forks"
@@ -334,6 +335,111 @@ var (
 		},
 	}
 
+	// CancunTestChainConfig contains every protocol change (EIPs) introduced
+	// and accepted by the Ethereum core developers up to and including the
+	// Cancun fork, for testing purposes. Later forks are not scheduled.
+	CancunTestChainConfig = &ChainConfig{
+		ChainID:                 big.NewInt(1),
+		HomesteadBlock:          big.NewInt(0),
+		DAOForkBlock:            nil,
+		DAOForkSupport:          false,
+		EIP150Block:             big.NewInt(0),
+		EIP155Block:             big.NewInt(0),
+		EIP158Block:             big.NewInt(0),
+		ByzantiumBlock:          big.NewInt(0),
+		ConstantinopleBlock:     big.NewInt(0),
+		PetersburgBlock:         big.NewInt(0),
+		IstanbulBlock:           big.NewInt(0),
+		MuirGlacierBlock:        big.NewInt(0),
+		BerlinBlock:             big.NewInt(0),
+		LondonBlock:             big.NewInt(0),
+		ArrowGlacierBlock:       big.NewInt(0),
+		GrayGlacierBlock:        big.NewInt(0),
+		MergeNetsplitBlock:      big.NewInt(0),
+		ShanghaiTime:            newUint64(0),
+		CancunTime:              newUint64(0),
+		PragueTime:              nil,
+		OsakaTime:               nil,
+		VerkleTime:              nil,
+		TerminalTotalDifficulty: big.NewInt(0),
+		Ethash:                  new(EthashConfig),
+		Clique:                  nil,
+		BlobScheduleConfig: &BlobScheduleConfig{
+			Cancun: DefaultCancunBlobConfig,
+		},
+	}
+
+	// PragueTestChainConfig contains every protocol change (EIPs) introduced
+	// and accepted by the Ethereum core developers up to and including the
+	// Prague fork, for testing purposes. Later forks are not scheduled.
+	PragueTestChainConfig = &ChainConfig{
+		ChainID:                 big.NewInt(1),
+		HomesteadBlock:          big.NewInt(0),
+		DAOForkBlock:            nil,
+		DAOForkSupport:          false,
+		EIP150Block:             big.NewInt(0),
+		EIP155Block:             big.NewInt(0),
+		EIP158Block:             big.NewInt(0),
+		ByzantiumBlock:          big.NewInt(0),
+		ConstantinopleBlock:     big.NewInt(0),
+		PetersburgBlock:         big.NewInt(0),
+		IstanbulBlock:           big.NewInt(0),
+		MuirGlacierBlock:        big.NewInt(0),
+		BerlinBlock:             big.NewInt(0),
+		LondonBlock:             big.NewInt(0),
+		ArrowGlacierBlock:       big.NewInt(0),
+		GrayGlacierBlock:        big.NewInt(0),
+		MergeNetsplitBlock:      big.NewInt(0),
+		ShanghaiTime:            newUint64(0),
+		CancunTime:              newUint64(0),
+		PragueTime:              newUint64(0),
+		OsakaTime:               nil,
+		VerkleTime:              nil,
+		TerminalTotalDifficulty: big.NewInt(0),
+		Ethash:                  new(EthashConfig),
+		Clique:                  nil,
+		BlobScheduleConfig: &BlobScheduleConfig{
+			Cancun: DefaultCancunBlobConfig,
+			Prague: DefaultPragueBlobConfig,
+		},
+	}
+
+	// OsakaTestChainConfig contains every protocol change (EIPs) introduced
+	// and accepted by the Ethereum core developers up to and including the
+	// Osaka fork, for testing purposes. Later forks are not scheduled.
+	OsakaTestChainConfig = &ChainConfig{
+		ChainID:                 big.NewInt(1),
+		HomesteadBlock:          big.NewInt(0),
+		DAOForkBlock:            nil,
+		DAOForkSupport:          false,
+		EIP150Block:             big.NewInt(0),
+		EIP155Block:             big.NewInt(0),
+		EIP158Block:             big.NewInt(0),
+		ByzantiumBlock:          big.NewInt(0),
+		ConstantinopleBlock:     big.NewInt(0),
+		PetersburgBlock:         big.NewInt(0),
+		IstanbulBlock:           big.NewInt(0),
+		MuirGlacierBlock:        big.NewInt(0),
+		BerlinBlock:             big.NewInt(0),
+		LondonBlock:             big.NewInt(0),
+		ArrowGlacierBlock:       big.NewInt(0),
+		GrayGlacierBlock:        big.NewInt(0),
+		MergeNetsplitBlock:      big.NewInt(0),
+		ShanghaiTime:            newUint64(0),
+		CancunTime:              newUint64(0),
+		PragueTime:              newUint64(0),
+		OsakaTime:               newUint64(0),
+		VerkleTime:              nil,
+		TerminalTotalDifficulty: big.NewInt(0),
+		Ethash:                  new(EthashConfig),
+		Clique:                  nil,
+		BlobScheduleConfig: &BlobScheduleConfig{
+			Cancun: DefaultCancunBlobConfig,
+			Prague: DefaultPragueBlobConfig,
+			Osaka:  DefaultOsakaBlobConfig,
+		},
+	}
+
 	// NonActivatedConfig defines the chain configuration without activating
 	// any protocol change (EIPs).
 	NonActivatedConfig = &ChainConfig{
@@ -712,6 +818,30 @@ func (bc *BlobConfig) String() string {
 	return fmt.Sprintf("target: %d, max: %d, fraction: %d", bc.Target, bc.Max, bc.UpdateFraction)
 }
 
+// BlobGasTarget returns the target blob gas per block, the target number of
+// blobs times the blob gas per blob. It must not be called on a nil config.
+func (bc *BlobConfig) BlobGasTarget() uint64 {
+	if bc == nil {
+		panic("blob gas target of nil blob config")
+	}
+	if bc.Target <= 0 {
+		return 0
+	}
+	return uint64(bc.Target) * BlobTxBlobGasPerBlob
+}
+
+// BlobGasMax returns the maximum blob gas per block, the maximum number of blobs
+// times the blob gas per blob. It must not be called on a nil config.
+func (bc *BlobConfig) BlobGasMax() uint64 {
+	if bc == nil {
+		panic("blob gas max of nil blob config")
+	}
+	if bc.Max <= 0 {
+		return 0
+	}
+	return uint64(bc.Max) * BlobTxBlobGasPerBlob
+}
+
 // BlobScheduleConfig determines target and max number of blobs allow per fork.
 type BlobScheduleConfig struct {
 	Cancun    *BlobConfig `json:"cancun,omitempty"`
@@ -726,6 +856,23 @@ type BlobScheduleConfig struct {
 	Amsterdam *BlobConfig `json:"amsterdam,omitempty"`
 }
 
+// equal reports whether two blob schedules contain the same configs for every fork.
+func (bs *BlobScheduleConfig) equal(other *BlobScheduleConfig) bool {
+	if bs == nil || other == nil {
+		return bs == other
+	}
+	return configValueEqual(bs.Cancun, other.Cancun) &&
+		configValueEqual(bs.Prague, other.Prague) &&
+		configValueEqual(bs.Osaka, other.Osaka) &&
+		configValueEqual(bs.Verkle, other.Verkle) &&
+		configValueEqual(bs.BPO1, other.BPO1) &&
+		configValueEqual(bs.BPO2, other.BPO2) &&
+		configValueEqual(bs.BPO3, other.BPO3) &&
+		configValueEqual(bs.BPO4, other.BPO4) &&
+		configValueEqual(bs.BPO5, other.BPO5) &&
+		configValueEqual(bs.Amsterdam, other.Amsterdam)
+}
+
 // IsHomestead returns whether num is either equal to the homestead block or greater.
 func (c *ChainConfig) IsHomestead(num *big.Int) bool {
 	return isBlockForked(c.HomesteadBlock, num)
@@ -866,6 +1013,18 @@ func (c *ChainConfig) IsAmsterdam(num *big.Int, time uint64) bool {
 	return c.IsLondon(num) && isTimestampForked(c.AmsterdamTime, time)
 }
 
+// IsBlobForkActive returns whether any fork enabling blob transactions (Cancun or
+// later) is active at the given time. Unlike IsCancun, it does not require a
+// block number, as all blob-enabling forks are timestamp based.
+func (c *ChainConfig) IsBlobForkActive(time uint64) bool {
+	for _, fork := range []*uint64{c.CancunTime, c.PragueTime, c.OsakaTime, c.BPO1Time, c.BPO2Time, c.BPO3Time, c.BPO4Time, c.BPO5Time, c.AmsterdamTime} {
+		if isTimestampForked(fork, time) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsVerkle returns whether time is either equal to the Verkle fork time or greater.
 func (c *ChainConfig) IsVerkle(num *big.Int, time uint64) bool {
 	return c.IsLondon(num) && isTimestampForked(c.VerkleTime, time)
@@ -890,6 +1049,65 @@ func (c *ChainConfig) IsEIP4762(num *big.Int, time uint64) bool {
 	return c.IsVerkle(num, time)
 }
 
+// Equal reports whether two chain configurations are identical. Unlike
+// reflect.DeepEqual, it compares block numbers and timestamps by value rather
+// than by pointer identity, while still distinguishing unset (nil) fields from
+// ones set to zero.
+func (c *ChainConfig) Equal(other *ChainConfig) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	for _, pair := range [][2]*big.Int{
+		{c.ChainID, other.ChainID},
+		{c.HomesteadBlock, other.HomesteadBlock},
+		{c.DAOForkBlock, other.DAOForkBlock},
+		{c.EIP150Block, other.EIP150Block},
+		{c.EIP155Block, other.EIP155Block},
+		{c.EIP158Block, other.EIP158Block},
+		{c.ByzantiumBlock, other.ByzantiumBlock},
+		{c.ConstantinopleBlock, other.ConstantinopleBlock},
+		{c.PetersburgBlock, other.PetersburgBlock},
+		{c.IstanbulBlock, other.IstanbulBlock},
+		{c.MuirGlacierBlock, other.MuirGlacierBlock},
+		{c.BerlinBlock, other.BerlinBlock},
+		{c.LondonBlock, other.LondonBlock},
+		{c.ArrowGlacierBlock, other.ArrowGlacierBlock},
+		{c.GrayGlacierBlock, other.GrayGlacierBlock},
+		{c.MergeNetsplitBlock, other.MergeNetsplitBlock},
+		{c.TerminalTotalDifficulty, other.TerminalTotalDifficulty},
+	} {
+		if !configBlockEqual(pair[0], pair[1]) {
+			return false
+		}
+	}
+	for _, pair := range [][2]*uint64{
+		{c.ShanghaiTime, other.ShanghaiTime},
+		{c.CancunTime, other.CancunTime},
+		{c.PragueTime, other.PragueTime},
+		{c.OsakaTime, other.OsakaTime},
+		{c.BPO1Time, other.BPO1Time},
+		{c.BPO2Time, other.BPO2Time},
+		{c.BPO3Time, other.BPO3Time},
+		{c.BPO4Time, other.BPO4Time},
+		{c.BPO5Time, other.BPO5Time},
+		{c.AmsterdamTime, other.AmsterdamTime},
+		{c.VerkleTime, other.VerkleTime},
+	} {
+		if !configTimestampEqual(pair[0], pair[1]) {
+			return false
+		}
+	}
+	if c.DAOForkSupport != other.DAOForkSupport ||
+		c.DepositContractAddress != other.DepositContractAddress ||
+		c.EnableVerkleAtGenesis != other.EnableVerkleAtGenesis {
+		return false
+	}
+	if !configValueEqual(c.Ethash, other.Ethash) || !configValueEqual(c.Clique, other.Clique) {
+		return false
+	}
+	return c.BlobScheduleConfig.equal(other.BlobScheduleConfig)
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64, time uint64) *ConfigCompatError {
@@ -1169,6 +1387,12 @@ func (c *ChainConfig) LatestFork(time uint64) forks.Fork {
 	}
 }
 
+// LatestForkName returns the human-readable name of the latest time-based fork
+// that would be active for the given time (e.g. "Prague"), for use in logs.
+func (c *ChainConfig) LatestForkName(time uint64) string {
+	return c.LatestFork(time).String()
+}
+
 // BlobConfig returns the blob config associated with the provided fork.
 func (c *ChainConfig) BlobConfig(fork forks.Fork) *BlobConfig {
 	switch fork {
@@ -1217,6 +1441,8 @@ func (c *ChainConfig) ActiveSystemContracts(time uint64) map[string]common.Addre
 // the fork isn't defined or isn't a time-based fork.
 func (c *ChainConfig) Timestamp(fork forks.Fork) *uint64 {
 	switch {
+	case fork == forks.Amsterdam:
+		return c.AmsterdamTime
 	case fork == forks.BPO5:
 		return c.BPO5Time
 	case fork == forks.BPO4:
@@ -1240,6 +1466,18 @@ func (c *ChainConfig) Timestamp(fork forks.Fork) *uint64 {
 	}
 }
 
+// ForkTimestamp returns the timestamp of the time-based fork with the given
+// case-insensitive name (e.g. "cancun"), or nil if the fork isn't scheduled.
+// An error is returned if the name does not identify a time-based fork.
+func (c *ChainConfig) ForkTimestamp(forkName string) (*uint64, error) {
+	for fork := forks.Shanghai; fork <= forks.Amsterdam; fork++ {
+		if strings.EqualFold(fork.String(), forkName) {
+			return c.Timestamp(fork), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown time-based fork %q", forkName)
+}
+
 // isForkBlockIncompatible returns true if a fork scheduled at block s1 cannot be
 // rescheduled to block s2 because head is already past the fork.
 func isForkBlockIncompatible(s1, s2, head *big.Int) bool {
@@ -1292,6 +1530,15 @@ func configTimestampEqual(x, y *uint64) bool {
 	return *x == *y
 }
 
+// configValueEqual reports whether two optional config sections are both unset
+// or both set to the same value.
+func configValueEqual[T comparable](x, y *T) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
 // ConfigCompatError is raised if the locally-stored blockchain is initialised with a
 // ChainConfig that would alter the past.
 type ConfigCompatError struct {
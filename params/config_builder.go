@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+// ChainConfigBuilder derives a chain config from a base one by toggling forks,
+// mostly meant to make the fork rules of tests explicit. The base config is
+// never modified.
+type ChainConfigBuilder struct {
+	config ChainConfig
+}
+
+// NewChainConfigBuilder creates a builder starting off from a copy of base.
+func NewChainConfigBuilder(base *ChainConfig) *ChainConfigBuilder {
+	return &ChainConfigBuilder{config: *base}
+}
+
+// EnableCancun schedules the Cancun fork at the given time.
+func (b *ChainConfigBuilder) EnableCancun(at uint64) *ChainConfigBuilder {
+	b.config.CancunTime = newUint64(at)
+	return b
+}
+
+// DisablePrague unschedules the Prague fork, along with all the forks following
+// it, which cannot be activated without Prague.
+func (b *ChainConfigBuilder) DisablePrague() *ChainConfigBuilder {
+	b.config.PragueTime = nil
+	b.config.OsakaTime = nil
+	b.config.BPO1Time = nil
+	b.config.BPO2Time = nil
+	b.config.BPO3Time = nil
+	b.config.BPO4Time = nil
+	b.config.BPO5Time = nil
+	b.config.AmsterdamTime = nil
+	return b
+}
+
+// SetBlobSchedule replaces the blob schedule of the config.
+func (b *ChainConfigBuilder) SetBlobSchedule(schedule *BlobScheduleConfig) *ChainConfigBuilder {
+	b.config.BlobScheduleConfig = schedule
+	return b
+}
+
+// Build returns the assembled chain config. The builder may be used to derive
+// further configs afterwards without affecting the returned one.
+func (b *ChainConfigBuilder) Build() *ChainConfig {
+	config := b.config
+	return &config
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import "testing"
+
+// Tests that the chain config builder toggles the requested forks, leaving the
+// base config untouched.
+func TestChainConfigBuilder(t *testing.T) {
+	schedule := &BlobScheduleConfig{Cancun: DefaultCancunBlobConfig}
+
+	builder := NewChainConfigBuilder(MergedTestChainConfig).
+		EnableCancun(10).
+		DisablePrague().
+		SetBlobSchedule(schedule)
+	config := builder.Build()
+
+	if config.CancunTime == nil || *config.CancunTime != 10 {
+		t.Errorf("cancun time mismatch: have %v, want 10", config.CancunTime)
+	}
+	if config.PragueTime != nil || config.OsakaTime != nil || config.BPO1Time != nil || config.AmsterdamTime != nil {
+		t.Errorf("prague and later forks not disabled")
+	}
+	if config.BlobScheduleConfig != schedule {
+		t.Errorf("blob schedule mismatch: have %v, want %v", config.BlobScheduleConfig, schedule)
+	}
+	if err := config.CheckConfigForkOrder(); err != nil {
+		t.Errorf("invalid fork order: %v", err)
+	}
+	if !config.IsCancun(config.LondonBlock, 10) || config.IsPrague(config.LondonBlock, 10) {
+		t.Errorf("fork rules mismatch at time 10")
+	}
+	// Ensure neither the base, nor previously built configs are modified
+	if MergedTestChainConfig.PragueTime == nil {
+		t.Errorf("base config modified")
+	}
+	builder.EnableCancun(20)
+	if *config.CancunTime != 10 {
+		t.Errorf("built config modified: cancun time %d", *config.CancunTime)
+	}
+}
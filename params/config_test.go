@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/params/forks"
 	"github.com/stretchr/testify/require"
 )
 
@@ -117,6 +118,53 @@ func TestCheckCompatible(t *testing.T) {
 		if !reflect.DeepEqual(err, test.wantErr) {
 			t.Errorf("error mismatch:\nstored: %v\nnew: %v\nheadBlock: %v\nheadTimestamp: %v\nerr: %v\nwant: %v", test.stored, test.new, test.headBlock, test.headTimestamp, err, test.wantErr)
 		}
+		if err != nil && test.stored.Equal(test.new) {
+			t.Errorf("identical configs reported incompatible:\nstored: %v\nnew: %v\nerr: %v", test.stored, test.new, err)
+		}
+	}
+}
+
+func TestChainConfigEqual(t *testing.T) {
+	copyConfig := func(c *ChainConfig) *ChainConfig {
+		cpy := *c
+		cpy.ChainID = new(big.Int).Set(c.ChainID)
+		if c.ShanghaiTime != nil {
+			cpy.ShanghaiTime = newUint64(*c.ShanghaiTime)
+		}
+		if c.BlobScheduleConfig != nil {
+			schedule := *c.BlobScheduleConfig
+			if schedule.Cancun != nil {
+				cancun := *schedule.Cancun
+				schedule.Cancun = &cancun
+			}
+			cpy.BlobScheduleConfig = &schedule
+		}
+		return &cpy
+	}
+	tests := []struct {
+		a, b *ChainConfig
+		want bool
+	}{
+		{nil, nil, true},
+		{MainnetChainConfig, nil, false},
+		{MainnetChainConfig, MainnetChainConfig, true},
+		{MainnetChainConfig, copyConfig(MainnetChainConfig), true},
+		{MainnetChainConfig, SepoliaChainConfig, false},
+		{&ChainConfig{ShanghaiTime: newUint64(0)}, &ChainConfig{}, false},
+		{&ChainConfig{ShanghaiTime: newUint64(10)}, &ChainConfig{ShanghaiTime: newUint64(10)}, true},
+		{&ChainConfig{ShanghaiTime: newUint64(10)}, &ChainConfig{ShanghaiTime: newUint64(20)}, false},
+		{&ChainConfig{LondonBlock: big.NewInt(0)}, &ChainConfig{}, false},
+		{&ChainConfig{Clique: &CliqueConfig{Period: 1}}, &ChainConfig{Clique: &CliqueConfig{Period: 1}}, true},
+		{&ChainConfig{Clique: &CliqueConfig{Period: 1}}, &ChainConfig{Clique: &CliqueConfig{Period: 2}}, false},
+		{&ChainConfig{BlobScheduleConfig: &BlobScheduleConfig{Cancun: DefaultCancunBlobConfig}}, &ChainConfig{BlobScheduleConfig: &BlobScheduleConfig{Cancun: DefaultPragueBlobConfig}}, false},
+	}
+	for i, tt := range tests {
+		if have := tt.a.Equal(tt.b); have != tt.want {
+			t.Errorf("test %d: equality mismatch: have %v, want %v", i, have, tt.want)
+		}
+		if have := tt.b.Equal(tt.a); have != tt.want {
+			t.Errorf("test %d: reverse equality mismatch: have %v, want %v", i, have, tt.want)
+		}
 	}
 }
 
@@ -139,6 +187,110 @@ func TestConfigRules(t *testing.T) {
 	}
 }
 
+func TestIsBlobForkActive(t *testing.T) {
+	tests := []struct {
+		config *ChainConfig
+		time   uint64
+		want   bool
+	}{
+		{&ChainConfig{}, math.MaxUint64, false},
+		{&ChainConfig{ShanghaiTime: newUint64(0)}, 1000, false},
+		{&ChainConfig{CancunTime: newUint64(500)}, 499, false},
+		{&ChainConfig{CancunTime: newUint64(500)}, 500, true},
+		{&ChainConfig{PragueTime: newUint64(500)}, 500, true},
+		{&ChainConfig{OsakaTime: newUint64(500)}, 1000, true},
+		{&ChainConfig{BPO2Time: newUint64(500)}, 499, false},
+		{&ChainConfig{BPO2Time: newUint64(500)}, 500, true},
+	}
+	for i, tt := range tests {
+		if have := tt.config.IsBlobForkActive(tt.time); have != tt.want {
+			t.Errorf("test %d: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that the fork-specific test configs activate exactly the forks up to
+// their namesake and are internally consistent.
+func TestForkTestChainConfigs(t *testing.T) {
+	tests := []struct {
+		config *ChainConfig
+		want   forks.Fork
+	}{
+		{CancunTestChainConfig, forks.Cancun},
+		{PragueTestChainConfig, forks.Prague},
+		{OsakaTestChainConfig, forks.Osaka},
+	}
+	for _, tt := range tests {
+		if err := tt.config.CheckConfigForkOrder(); err != nil {
+			t.Errorf("%v: invalid fork order: %v", tt.want, err)
+		}
+		if have := tt.config.LatestFork(math.MaxUint64); have != tt.want {
+			t.Errorf("latest fork mismatch: have %v, want %v", have, tt.want)
+		}
+	}
+}
+
+// Tests that the latest fork name tracks the time based fork activations.
+func TestLatestForkName(t *testing.T) {
+	c := &ChainConfig{
+		LondonBlock:  new(big.Int),
+		ShanghaiTime: newUint64(10),
+		CancunTime:   newUint64(20),
+		PragueTime:   newUint64(30),
+		OsakaTime:    newUint64(40),
+	}
+	tests := []struct {
+		time uint64
+		want string
+	}{
+		{0, "Paris"},
+		{10, "Shanghai"},
+		{29, "Cancun"},
+		{30, "Prague"},
+		{math.MaxUint64, "Osaka"},
+	}
+	for _, tt := range tests {
+		if have := c.LatestForkName(tt.time); have != tt.want {
+			t.Errorf("time %d: fork name mismatch: have %q, want %q", tt.time, have, tt.want)
+		}
+	}
+}
+
+// Tests that fork timestamps can be looked up by case-insensitive name, and that
+// only time-based forks are accepted.
+func TestForkTimestamp(t *testing.T) {
+	c := &ChainConfig{
+		LondonBlock:   new(big.Int),
+		CancunTime:    newUint64(20),
+		PragueTime:    newUint64(30),
+		AmsterdamTime: newUint64(50),
+	}
+	tests := []struct {
+		name string
+		want *uint64
+		fail bool
+	}{
+		{"cancun", newUint64(20), false},
+		{"Prague", newUint64(30), false},
+		{"OSAKA", nil, false},
+		{"bpo1", nil, false},
+		{"amsterdam", newUint64(50), false},
+		{"london", nil, true},
+		{"verkle", nil, true},
+		{"", nil, true},
+	}
+	for _, tt := range tests {
+		have, err := c.ForkTimestamp(tt.name)
+		if (err != nil) != tt.fail {
+			t.Errorf("fork %q: error mismatch: have %v, want failure %v", tt.name, err, tt.fail)
+			continue
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("fork %q: timestamp mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
 func TestTimestampCompatError(t *testing.T) {
 	require.Equal(t, new(ConfigCompatError).Error(), "")
 
@@ -17,6 +17,7 @@
 package rlp
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -85,6 +86,24 @@ func EncodeToBytes(val interface{}) ([]byte, error) {
 	return buf.makeBytes(), nil
 }
 
+// EncodeTo appends the RLP encoding of val to buf. Unlike EncodeToBytes, the
+// output is not allocated by the call, so callers encoding many values may reset
+// and reuse the same buffer across calls.
+func EncodeTo(buf *bytes.Buffer, val interface{}) error {
+	eb := getEncBuffer()
+	defer encBufferPool.Put(eb)
+
+	if err := eb.encode(val); err != nil {
+		return err
+	}
+	size := eb.size()
+	buf.Grow(size)
+	out := buf.AvailableBuffer()[:size]
+	eb.copyTo(out)
+	buf.Write(out)
+	return nil
+}
+
 // EncodeToReader returns a reader from which the RLP encoding of val
 // can be read. The returned size is the total size of the encoded
 // data.
@@ -433,6 +433,21 @@ func TestEncodeToBytes(t *testing.T) {
 	runEncTests(t, EncodeToBytes)
 }
 
+func TestEncodeTo(t *testing.T) {
+	var buf bytes.Buffer
+	runEncTests(t, func(val interface{}) ([]byte, error) {
+		buf.Reset()
+		buf.WriteString("prefix")
+		if err := EncodeTo(&buf, val); err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("prefix")) {
+			return nil, errors.New("existing buffer content overwritten")
+		}
+		return buf.Bytes()[len("prefix"):], nil
+	})
+}
+
 func TestEncodeAppendToBytes(t *testing.T) {
 	buffer := make([]byte, 20)
 	runEncTests(t, func(val interface{}) ([]byte, error) {
@@ -608,6 +623,33 @@ func BenchmarkEncodeByteArrayStruct(b *testing.B) {
 	}
 }
 
+// BenchmarkEncodeToBytesLoop and BenchmarkEncodeToLoop compare assembling many
+// messages into fresh byte slices versus reusing a single buffer.
+func BenchmarkEncodeToBytesLoop(b *testing.B) {
+	value := byteArrayStruct{}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeToBytes(&value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeToLoop(b *testing.B) {
+	var (
+		buf   bytes.Buffer
+		value byteArrayStruct
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := EncodeTo(&buf, &value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 type structSliceElem struct {
 	X uint64
 	Y uint64
@@ -17,6 +17,7 @@
 package rlp
 
 import (
+	"fmt"
 	"io"
 	"reflect"
 )
@@ -152,6 +153,34 @@ func CountValues(b []byte) (int, error) {
 	return i, nil
 }
 
+// DecodeList decodes the RLP list in b, calling decode with the encoding of each
+// of its elements in turn. It returns ErrMoreThanOneValue if b contains data
+// after the list.
+func DecodeList[T any](b []byte, decode func([]byte) (T, error)) ([]T, error) {
+	content, rest, err := SplitList(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, ErrMoreThanOneValue
+	}
+	n, err := CountValues(content)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, 0, n)
+	for len(content) > 0 {
+		_, tagsize, size, _ := readKind(content) // validated by CountValues
+		item, err := decode(content[:tagsize+size])
+		if err != nil {
+			return nil, fmt.Errorf("list element %d: %w", len(items), err)
+		}
+		items = append(items, item)
+		content = content[tagsize+size:]
+	}
+	return items, nil
+}
+
 func readKind(buf []byte) (k Kind, tagsize, contentsize uint64, err error) {
 	if len(buf) == 0 {
 		return 0, 0, 0, io.ErrUnexpectedEOF
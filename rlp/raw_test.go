@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"reflect"
 	"testing"
 	"testing/quick"
 )
@@ -92,6 +93,44 @@ func TestSplitList(t *testing.T) {
 	}
 }
 
+func TestDecodeList(t *testing.T) {
+	decodeUint := func(b []byte) (uint64, error) {
+		var x uint64
+		err := DecodeBytes(b, &x)
+		return x, err
+	}
+	errBadItem := errors.New("bad item")
+	tests := []struct {
+		input  string
+		decode func([]byte) (uint64, error)
+		want   []uint64
+		err    error
+	}{
+		{input: "C0", decode: decodeUint, want: []uint64{}},
+		{input: "C3010203", decode: decodeUint, want: []uint64{1, 2, 3}},
+		{input: "C7820400830A0B0C", decode: decodeUint, want: []uint64{0x0400, 0x0A0B0C}},
+		{input: "01", decode: decodeUint, err: ErrExpectedList},
+		{input: "C101 02", decode: decodeUint, err: ErrMoreThanOneValue},
+		{input: "C3018202", decode: decodeUint, err: ErrValueTooLarge},
+		{input: "C20180", decode: func(b []byte) (uint64, error) {
+			if b[0] == 0x80 {
+				return 0, errBadItem
+			}
+			return decodeUint(b)
+		}, err: errBadItem},
+	}
+	for i, test := range tests {
+		have, err := DecodeList(unhex(test.input), test.decode)
+		if !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(have, test.want) {
+			t.Errorf("test %d: value mismatch: have %v, want %v", i, have, test.want)
+		}
+	}
+}
+
 func TestSplitUint64(t *testing.T) {
 	tests := []struct {
 		input string
@@ -282,7 +282,7 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 	// - the block body is verified against the header in block_validator.go:ValidateBody
 	// Here, we just do this shortcut smaller fix, since state tests do not
 	// utilize those codepaths.
-	if config.IsCancun(new(big.Int), block.Time()) {
+	if config.IsBlobForkActive(block.Time()) {
 		if len(msg.BlobHashes) > eip4844.MaxBlobsPerBlock(config, block.Time()) {
 			return st, common.Hash{}, 0, errors.New("blob gas exceeds maximum")
 		}
@@ -313,7 +313,7 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 		context.Random = &rnd
 		context.Difficulty = big.NewInt(0)
 	}
-	if config.IsCancun(new(big.Int), block.Time()) && t.json.Env.ExcessBlobGas != nil {
+	if config.IsBlobForkActive(block.Time()) && t.json.Env.ExcessBlobGas != nil {
 		header := &types.Header{
 			Time:          block.Time(),
 			ExcessBlobGas: t.json.Env.ExcessBlobGas,